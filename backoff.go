@@ -0,0 +1,46 @@
+package timeManagement
+
+import (
+	"math"
+	"time"
+)
+
+// BackoffPolicy 指數退避參數
+type BackoffPolicy struct {
+	// 第一次重試前的等待時間
+	Initial time.Duration
+	// 單次等待時間上限，非正數表示不設上限
+	Max time.Duration
+	// 每次重試等待時間的倍數，小於 1 時視為 1
+	Multiplier float64
+}
+
+// Backoff 返回第 attempt 次重試（從 0 起算）前的等待時間：Initial * Multiplier^attempt，不超過 Max
+func (p BackoffPolicy) Backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	delay := float64(p.Initial) * math.Pow(multiplier, float64(attempt))
+	if p.Max > 0 && delay > float64(p.Max) {
+		return p.Max
+	}
+	if delay > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return time.Duration(delay)
+}
+
+// RetrySchedule 返回自 start 起依退避策略累計等待後，每次重試的絕對時間
+func RetrySchedule(start time.Time, policy BackoffPolicy, maxRetries int) []time.Time {
+	if maxRetries <= 0 {
+		return nil
+	}
+	schedule := make([]time.Time, maxRetries)
+	next := start.UTC()
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		next = next.Add(policy.Backoff(attempt))
+		schedule[attempt] = next
+	}
+	return schedule
+}
@@ -0,0 +1,35 @@
+package timeManagement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackoff(t *testing.T) {
+	policy := BackoffPolicy{Initial: time.Second, Max: 10 * time.Second, Multiplier: 2}
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second}
+	for attempt, delay := range expected {
+		assert.Equal(t, delay, policy.Backoff(attempt), "Expected backoff for attempt %d to match", attempt)
+	}
+
+	constant := BackoffPolicy{Initial: time.Second}
+	assert.Equal(t, time.Second, constant.Backoff(5), "Expected multiplier below 1 to keep a constant delay")
+}
+
+func TestRetrySchedule(t *testing.T) {
+	start := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	policy := BackoffPolicy{Initial: time.Second, Max: 5 * time.Second, Multiplier: 2}
+
+	schedule := RetrySchedule(start, policy, 5)
+	// 累計等待 1s、1+2s、1+2+4s，之後每次加上上限 5s
+	expected := []time.Duration{time.Second, 3 * time.Second, 7 * time.Second, 12 * time.Second, 17 * time.Second}
+	require.Len(t, schedule, len(expected), "Expected one timestamp per retry")
+	for i, offset := range expected {
+		assert.Equal(t, start.Add(offset), schedule[i], "Expected retry %d at the cumulative backoff offset", i)
+	}
+
+	assert.Empty(t, RetrySchedule(start, policy, 0), "Expected no retries for zero maxRetries")
+}
@@ -0,0 +1,215 @@
+package timeManagement
+
+import (
+	"time"
+)
+
+// civilDate 不含時區的日曆日期
+type civilDate struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+func civilDateOf(t time.Time) civilDate {
+	year, month, day := t.Date()
+	return civilDate{year, month, day}
+}
+
+// isWeekendDate 以 local 本身的星期判斷是否為週六或週日，不做時區轉換
+func isWeekendDate(local time.Time) bool {
+	switch local.Weekday() {
+	case time.Saturday, time.Sunday:
+		return true
+	}
+	return false
+}
+
+// IsWeekend 判斷 t 在 loc 時區是否為週六或週日
+func IsWeekend(t time.Time, loc *time.Location) bool {
+	return isWeekendDate(t.In(loc))
+}
+
+// IsBusinessDay 判斷 t 在 loc 時區是否為營業日，週末及 holidays 中任一日期（以 loc 時區的日曆日比較）視為非營業日
+func IsBusinessDay(t time.Time, loc *time.Location, holidays []time.Time) bool {
+	local := t.In(loc)
+	if isWeekendDate(local) {
+		return false
+	}
+	date := civilDateOf(local)
+	for _, holiday := range holidays {
+		if civilDateOf(holiday.In(loc)) == date {
+			return false
+		}
+	}
+	return true
+}
+
+// AddBusinessDays 返回 t 在 loc 時區前進 n 個營業日（n 為負數時後退）後的時間（UTC），保留當地時刻，
+// 跳過週末及假日；從非營業日開始時第一步落在下一個（或上一個）營業日，n 為 0 時返回 t
+func AddBusinessDays(t time.Time, n int, loc *time.Location, holidays []time.Time) time.Time {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	local := t.In(loc)
+	for n > 0 {
+		local = local.AddDate(0, 0, step)
+		if IsBusinessDay(local, loc, holidays) {
+			n--
+		}
+	}
+	return local.UTC()
+}
+
+// annualDate 每年重複的月日
+type annualDate struct {
+	month time.Month
+	day   int
+}
+
+// BusinessCalendar 營業日曆，定義時區、每日工作時段及假日，週六及週日視為非營業日
+type BusinessCalendar struct {
+	provider          TimeProvider
+	location          *time.Location
+	workStart         time.Duration
+	workEnd           time.Duration
+	holidays          map[civilDate]struct{}
+	recurringHolidays map[annualDate]struct{}
+}
+
+// DayCoverage 一個營業日與指定時間範圍在工作時段內重疊的時長
+type DayCoverage struct {
+	// 當地日期的午夜，午夜因日光節約時間不存在時為當天的第一個時刻
+	Date     time.Time
+	Duration time.Duration
+}
+
+// NewBusinessCalendar 以預設的時間提供者建立營業日曆，workStart 與 workEnd 為自當地午夜起算的上下班時間
+func NewBusinessCalendar(loc *time.Location, workStart, workEnd time.Duration) *BusinessCalendar {
+	return GetProvider().NewBusinessCalendar(loc, workStart, workEnd)
+}
+
+func (r *realTimeProvider) NewBusinessCalendar(loc *time.Location, workStart, workEnd time.Duration) *BusinessCalendar {
+	return &BusinessCalendar{
+		provider:          r,
+		location:          loc,
+		workStart:         workStart,
+		workEnd:           workEnd,
+		holidays:          make(map[civilDate]struct{}),
+		recurringHolidays: make(map[annualDate]struct{}),
+	}
+}
+
+// AddHoliday 新增假日，以 date 本身的年月日為準
+func (c *BusinessCalendar) AddHoliday(date time.Time) {
+	c.holidays[civilDateOf(date)] = struct{}{}
+}
+
+// AddRecurringHoliday 新增每年重複的假日，例如元旦
+func (c *BusinessCalendar) AddRecurringHoliday(month time.Month, day int) {
+	c.recurringHolidays[annualDate{month, day}] = struct{}{}
+}
+
+// isHoliday 以 local 本身的日期判斷是否為單次或每年重複的假日
+func (c *BusinessCalendar) isHoliday(local time.Time) bool {
+	date := civilDateOf(local)
+	if _, ok := c.holidays[date]; ok {
+		return true
+	}
+	_, ok := c.recurringHolidays[annualDate{date.month, date.day}]
+	return ok
+}
+
+// HolidaysInRange 返回 loc 時區下 start 至 end 的日期（含頭尾）中設定為假日的日期，
+// 以當地午夜（午夜不存在時為當天的第一個時刻）表示並依時間排序
+func (c *BusinessCalendar) HolidaysInRange(start, end time.Time, loc *time.Location) []time.Time {
+	var holidays []time.Time
+	days := CalendarDaysBetween(start, end, loc)
+	year, month, day := start.In(loc).Date()
+	for i := 0; i <= days; i++ {
+		// 以正午判斷日期，避免午夜因日光節約時間不存在
+		if c.isHoliday(time.Date(year, month, day+i, 12, 0, 0, 0, loc)) {
+			holidays = append(holidays, localMidnight(year, month, day+i, loc))
+		}
+	}
+	return holidays
+}
+
+// IsBusinessDay 判斷 t 在日曆時區下是否為營業日
+func (c *BusinessCalendar) IsBusinessDay(t time.Time) bool {
+	return c.isBusinessDate(t.In(c.location))
+}
+
+// isBusinessDate 以 local 本身的日期判斷是否為營業日，不做時區轉換
+func (c *BusinessCalendar) isBusinessDate(local time.Time) bool {
+	return !isWeekendDate(local) && !c.isHoliday(local)
+}
+
+// workingHours 返回 date 所在當地日期的上下班時間
+func (c *BusinessCalendar) workingHours(date time.Time) (time.Time, time.Time) {
+	year, month, day := date.In(c.location).Date()
+	return time.Date(year, month, day, 0, 0, 0, int(c.workStart), c.location),
+		time.Date(year, month, day, 0, 0, 0, int(c.workEnd), c.location)
+}
+
+// BusinessHoursInRange 返回 start 至 end 範圍內每個營業日落在工作時段內的時長，跳過週末及假日
+func (c *BusinessCalendar) BusinessHoursInRange(start, end time.Time) []DayCoverage {
+	var coverage []DayCoverage
+	year, month, day := start.In(c.location).Date()
+	for ; ; day++ {
+		date := localMidnight(year, month, day, c.location)
+		if !date.Before(end) {
+			return coverage
+		}
+		// 以正午判斷日期，避免午夜因日光節約時間不存在
+		noon := time.Date(year, month, day, 12, 0, 0, 0, c.location)
+		if !c.isBusinessDate(noon) {
+			continue
+		}
+		workStart, workEnd := c.workingHours(noon)
+		if start.After(workStart) {
+			workStart = start
+		}
+		if end.Before(workEnd) {
+			workEnd = end
+		}
+		var covered time.Duration
+		if workEnd.After(workStart) {
+			covered = workEnd.Sub(workStart)
+		}
+		coverage = append(coverage, DayCoverage{Date: date, Duration: covered})
+	}
+}
+
+// NextBusinessDayStart 返回 t 之後下一個營業日在 loc 時區 startHour:startMin 的開始時間（UTC）
+// t 為營業日且早於當天的開始時間時返回當天的開始時間
+func (c *BusinessCalendar) NextBusinessDayStart(t time.Time, startHour, startMin int, loc *time.Location) time.Time {
+	year, month, day := t.In(loc).Date()
+	candidate := time.Date(year, month, day, startHour, startMin, 0, 0, loc)
+	if !t.Before(candidate) || !c.isBusinessDate(candidate) {
+		for {
+			day++
+			candidate = time.Date(year, month, day, startHour, startMin, 0, 0, loc)
+			if c.isBusinessDate(candidate) {
+				break
+			}
+		}
+	}
+	return candidate.UTC()
+}
+
+// BusinessAge 返回 created 至當前時間經過的完整營業日數，即在日曆時區下 created 當天之後、
+// 今天之前的營業日數，created 當天及尚未結束的今天皆不計入，跳過週末及假日，created 在未來時返回 0
+func (c *BusinessCalendar) BusinessAge(created time.Time) int {
+	days := CalendarDaysBetween(created, c.provider.Now(), c.location)
+	year, month, day := created.In(c.location).Date()
+	age := 0
+	for i := 1; i < days; i++ {
+		// 以正午判斷日期，避免午夜因日光節約時間不存在
+		if c.isBusinessDate(time.Date(year, month, day+i, 12, 0, 0, 0, c.location)) {
+			age++
+		}
+	}
+	return age
+}
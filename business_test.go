@@ -0,0 +1,261 @@
+package timeManagement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBusinessCalendar(t *testing.T) *BusinessCalendar {
+	location, err := time.LoadLocation("Asia/Taipei")
+	require.NoError(t, err, "Failed to load location")
+	return NewBusinessCalendar(location, 9*time.Hour, 18*time.Hour)
+}
+
+func TestBusinessHoursInRange(t *testing.T) {
+	calendar := newTestBusinessCalendar(t)
+	location := calendar.location
+	// 2023-01-09 為週一，設為假日
+	calendar.AddHoliday(time.Date(2023, 1, 9, 0, 0, 0, 0, location))
+
+	// 週五 15:00 至下週三 11:00
+	start := time.Date(2023, 1, 6, 15, 0, 0, 0, location)
+	end := time.Date(2023, 1, 11, 11, 0, 0, 0, location)
+	coverage := calendar.BusinessHoursInRange(start.UTC(), end.UTC())
+
+	expected := []DayCoverage{
+		{time.Date(2023, 1, 6, 0, 0, 0, 0, location), 3 * time.Hour},
+		{time.Date(2023, 1, 10, 0, 0, 0, 0, location), 9 * time.Hour},
+		{time.Date(2023, 1, 11, 0, 0, 0, 0, location), 2 * time.Hour},
+	}
+	require.Len(t, coverage, len(expected), "Expected coverage for each business day")
+	for i, day := range coverage {
+		assert.True(t, expected[i].Date.Equal(day.Date), "Expected date %v, got %v", expected[i].Date, day.Date)
+		assert.Equal(t, expected[i].Duration, day.Duration, "Expected coverage on %v to match", day.Date)
+	}
+}
+
+func TestBusinessHoursInRangeOutsideWorkingHours(t *testing.T) {
+	calendar := newTestBusinessCalendar(t)
+	location := calendar.location
+
+	start := time.Date(2023, 1, 4, 19, 0, 0, 0, location)
+	end := time.Date(2023, 1, 5, 8, 0, 0, 0, location)
+	coverage := calendar.BusinessHoursInRange(start, end)
+
+	require.Len(t, coverage, 2, "Expected both business days to be reported")
+	for _, day := range coverage {
+		assert.Zero(t, day.Duration, "Expected no coverage outside working hours")
+	}
+}
+
+func TestBusinessHoursInRangeMissingMidnight(t *testing.T) {
+	havana, err := time.LoadLocation("America/Havana")
+	require.NoError(t, err, "Failed to load location")
+	calendar := NewBusinessCalendar(havana, 9*time.Hour, 18*time.Hour)
+
+	// 哈瓦那 2023-03-12（週日）於午夜切換為夏令時間，週一仍以當地午夜表示
+	start := time.Date(2023, 3, 10, 12, 0, 0, 0, havana)
+	end := time.Date(2023, 3, 14, 12, 0, 0, 0, havana)
+	coverage := calendar.BusinessHoursInRange(start, end)
+
+	expected := []DayCoverage{
+		{time.Date(2023, 3, 10, 0, 0, 0, 0, havana), 6 * time.Hour},
+		{time.Date(2023, 3, 13, 0, 0, 0, 0, havana), 9 * time.Hour},
+		{time.Date(2023, 3, 14, 0, 0, 0, 0, havana), 3 * time.Hour},
+	}
+	require.Len(t, coverage, len(expected), "Expected coverage for each business day")
+	for i, day := range coverage {
+		assert.True(t, expected[i].Date.Equal(day.Date), "Expected date %v, got %v", expected[i].Date, day.Date)
+		assert.Equal(t, expected[i].Duration, day.Duration, "Expected coverage on %v to match", day.Date)
+	}
+}
+
+func TestNextBusinessDayStart(t *testing.T) {
+	calendar := newTestBusinessCalendar(t)
+	location := calendar.location
+	// 2023-01-20 為週五，設 2023-01-24（週二）為假日
+	calendar.AddHoliday(time.Date(2023, 1, 24, 0, 0, 0, 0, location))
+
+	tests := []struct {
+		name     string
+		t        time.Time
+		expected time.Time
+	}{
+		{"friday afternoon", time.Date(2023, 1, 20, 15, 0, 0, 0, location), time.Date(2023, 1, 23, 9, 0, 0, 0, location)},
+		{"pre-holiday day", time.Date(2023, 1, 23, 10, 0, 0, 0, location), time.Date(2023, 1, 25, 9, 0, 0, 0, location)},
+		{"mid-morning weekday", time.Date(2023, 1, 18, 10, 30, 0, 0, location), time.Date(2023, 1, 19, 9, 0, 0, 0, location)},
+		{"before business start", time.Date(2023, 1, 18, 7, 0, 0, 0, location), time.Date(2023, 1, 18, 9, 0, 0, 0, location)},
+		{"saturday", time.Date(2023, 1, 21, 7, 0, 0, 0, location), time.Date(2023, 1, 23, 9, 0, 0, 0, location)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := calendar.NextBusinessDayStart(tt.t, 9, 0, location)
+			assert.True(t, tt.expected.Equal(next), "Expected %v, got %v", tt.expected, next)
+			assert.Equal(t, time.UTC, next.Location(), "Expected UTC result")
+		})
+	}
+}
+
+func TestHolidaysInRange(t *testing.T) {
+	calendar := newTestBusinessCalendar(t)
+	location := calendar.location
+	calendar.AddRecurringHoliday(time.January, 1)
+	calendar.AddRecurringHoliday(time.October, 10)
+	calendar.AddHoliday(time.Date(2023, 1, 23, 0, 0, 0, 0, location))
+	calendar.AddHoliday(time.Date(2024, 2, 8, 0, 0, 0, 0, location))
+
+	// 2023-10-01 至 2024-03-31（含頭尾）
+	start := time.Date(2023, 10, 1, 12, 0, 0, 0, location)
+	end := time.Date(2024, 3, 31, 23, 0, 0, 0, location)
+	holidays := calendar.HolidaysInRange(start.UTC(), end.UTC(), location)
+
+	expected := []time.Time{
+		time.Date(2023, 10, 10, 0, 0, 0, 0, location),
+		time.Date(2024, 1, 1, 0, 0, 0, 0, location),
+		time.Date(2024, 2, 8, 0, 0, 0, 0, location),
+	}
+	require.Len(t, holidays, len(expected), "Expected recurring and one-off holidays in range")
+	for i, holiday := range holidays {
+		assert.True(t, expected[i].Equal(holiday), "Expected holiday %v, got %v", expected[i], holiday)
+	}
+
+	assert.False(t, calendar.IsBusinessDay(time.Date(2025, 1, 1, 10, 0, 0, 0, location)), "Expected recurring holiday to apply every year")
+	assert.Empty(t, calendar.HolidaysInRange(end, end, location), "Expected no holidays on a single non-holiday date")
+
+	// 哈瓦那 2023-03-12 於午夜切換為夏令時間，之後的日期仍以當地午夜表示
+	havana, err := time.LoadLocation("America/Havana")
+	require.NoError(t, err, "Failed to load location")
+	calendar.AddHoliday(time.Date(2023, 3, 11, 0, 0, 0, 0, time.UTC))
+	calendar.AddHoliday(time.Date(2023, 3, 12, 0, 0, 0, 0, time.UTC))
+	calendar.AddHoliday(time.Date(2023, 3, 14, 0, 0, 0, 0, time.UTC))
+	holidays = calendar.HolidaysInRange(time.Date(2023, 3, 10, 12, 0, 0, 0, havana), time.Date(2023, 3, 15, 12, 0, 0, 0, havana), havana)
+	expected = []time.Time{
+		time.Date(2023, 3, 11, 0, 0, 0, 0, havana),
+		time.Date(2023, 3, 12, 1, 0, 0, 0, havana),
+		time.Date(2023, 3, 14, 0, 0, 0, 0, havana),
+	}
+	require.Len(t, holidays, len(expected), "Expected each holiday once across the missing midnight")
+	for i, holiday := range holidays {
+		assert.True(t, expected[i].Equal(holiday), "Expected holiday %v, got %v", expected[i], holiday)
+	}
+}
+
+func TestBusinessAge(t *testing.T) {
+	calendar := newTestBusinessCalendar(t)
+	location := calendar.location
+	// 2023-01-17 為週二，設為假日
+	calendar.AddHoliday(time.Date(2023, 1, 17, 0, 0, 0, 0, location))
+
+	provider := GetProvider()
+	// 2023-01-18 週三 10:00
+	mockTime := time.Date(2023, 1, 18, 10, 0, 0, 0, location)
+	provider.SetMockTime(mockTime)
+	defer provider.ClearMockTime()
+
+	tests := []struct {
+		name     string
+		created  time.Time
+		expected int
+	}{
+		// 週五及週一為完整的營業日，週末及週二假日跳過，尚未結束的週三不計入
+		{"Created on Thursday", time.Date(2023, 1, 12, 15, 0, 0, 0, location), 2},
+		{"Created on Friday", time.Date(2023, 1, 13, 17, 0, 0, 0, location), 1},
+		{"Created on Monday", time.Date(2023, 1, 16, 9, 0, 0, 0, location), 0},
+		{"Created yesterday", time.Date(2023, 1, 17, 23, 0, 0, 0, location), 0},
+		{"Created earlier today", time.Date(2023, 1, 18, 8, 0, 0, 0, location), 0},
+		{"Created in the future", mockTime.Add(48 * time.Hour), 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, calendar.BusinessAge(test.created.UTC()), "Expected business age to match")
+		})
+	}
+}
+
+func TestBusinessAgeProvider(t *testing.T) {
+	location, err := time.LoadLocation("Asia/Taipei")
+	require.NoError(t, err, "Failed to load location")
+	provider := NewProvider()
+	calendar := provider.NewBusinessCalendar(location, 9*time.Hour, 18*time.Hour)
+
+	// 2023-01-12 週四 15:00 建立，週五中午時週五尚未結束
+	created := time.Date(2023, 1, 12, 15, 0, 0, 0, location)
+	provider.SetFrozenTime(time.Date(2023, 1, 13, 12, 0, 0, 0, location))
+	assert.Equal(t, 0, calendar.BusinessAge(created), "Expected a partial day not to count")
+
+	provider.SetFrozenTime(time.Date(2023, 1, 16, 0, 0, 0, 0, location))
+	assert.Equal(t, 1, calendar.BusinessAge(created), "Expected Friday to count once it has ended")
+
+	// 日曆使用注入的時間提供者，不受預設提供者影響
+	GetProvider().SetMockTime(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+	defer GetProvider().ClearMockTime()
+	assert.Equal(t, 1, calendar.BusinessAge(created), "Expected calendar to use its own provider")
+}
+
+func TestIsWeekend(t *testing.T) {
+	location, err := time.LoadLocation("Asia/Taipei")
+	require.NoError(t, err, "Failed to load location")
+
+	// UTC 週五 18:00 在台北已是週六
+	friday := time.Date(2023, 1, 6, 18, 0, 0, 0, time.UTC)
+	assert.False(t, IsWeekend(friday, time.UTC), "Expected Friday in UTC not to be a weekend")
+	assert.True(t, IsWeekend(friday, location), "Expected Saturday in Taipei to be a weekend")
+	assert.True(t, IsWeekend(time.Date(2023, 1, 8, 12, 0, 0, 0, time.UTC), time.UTC), "Expected Sunday to be a weekend")
+}
+
+func TestIsBusinessDayWithHolidays(t *testing.T) {
+	location, err := time.LoadLocation("Asia/Taipei")
+	require.NoError(t, err, "Failed to load location")
+	// 2023-01-11 為週三
+	holidays := []time.Time{time.Date(2023, 1, 11, 0, 0, 0, 0, location)}
+
+	tests := []struct {
+		name     string
+		t        time.Time
+		expected bool
+	}{
+		{"Wednesday holiday", time.Date(2023, 1, 11, 10, 0, 0, 0, location), false},
+		{"Holiday compared by local date", time.Date(2023, 1, 10, 16, 30, 0, 0, time.UTC), false},
+		{"Regular Tuesday", time.Date(2023, 1, 10, 10, 0, 0, 0, location), true},
+		{"Saturday", time.Date(2023, 1, 14, 10, 0, 0, 0, location), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsBusinessDay(tt.t, location, holidays), "Expected business day result to match")
+		})
+	}
+}
+
+func TestAddBusinessDays(t *testing.T) {
+	location, err := time.LoadLocation("Asia/Taipei")
+	require.NoError(t, err, "Failed to load location")
+	// 2023-01-11 為週三，設為假日
+	holidays := []time.Time{time.Date(2023, 1, 11, 0, 0, 0, 0, location)}
+
+	tests := []struct {
+		name     string
+		t        time.Time
+		n        int
+		expected time.Time
+	}{
+		{"Friday plus one", time.Date(2023, 1, 6, 15, 0, 0, 0, location), 1, time.Date(2023, 1, 9, 15, 0, 0, 0, location)},
+		{"Skip holiday", time.Date(2023, 1, 10, 9, 0, 0, 0, location), 1, time.Date(2023, 1, 12, 9, 0, 0, 0, location)},
+		{"Start on weekend", time.Date(2023, 1, 7, 9, 0, 0, 0, location), 1, time.Date(2023, 1, 9, 9, 0, 0, 0, location)},
+		{"Zero days", time.Date(2023, 1, 7, 9, 0, 0, 0, location), 0, time.Date(2023, 1, 7, 9, 0, 0, 0, location)},
+		{"Monday minus one", time.Date(2023, 1, 9, 9, 0, 0, 0, location), -1, time.Date(2023, 1, 6, 9, 0, 0, 0, location)},
+		{"Across weekend and holiday", time.Date(2023, 1, 6, 9, 0, 0, 0, location), 4, time.Date(2023, 1, 13, 9, 0, 0, 0, location)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := AddBusinessDays(tt.t, tt.n, location, holidays)
+			assert.Equal(t, tt.expected.UTC(), result, "Expected business day result to match")
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package timeManagement
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// FiscalPeriod 返回 t 在 loc 時區下所屬的會計年度及期別（1-12）
+// 會計年度自 fiscalStartMonth 開始，並以會計年度結束時的曆年命名，
+// 例如四月制下 2023 年 4 月至 2024 年 3 月屬於 2024 會計年度
+func FiscalPeriod(t time.Time, fiscalStartMonth time.Month, loc *time.Location) (fiscalYear int, period int) {
+	local := t.In(loc)
+	year, month := local.Year(), local.Month()
+
+	period = int(month-fiscalStartMonth+12)%12 + 1
+	fiscalYear = year
+	if fiscalStartMonth != time.January && month >= fiscalStartMonth {
+		fiscalYear++
+	}
+	return fiscalYear, period
+}
+
+// CivilDayLength 返回 date 在 loc 時區的當地日曆日實際長度，
+// 日光節約時間切換日為 23 或 25 小時（牆上時鐘的日長，與日照長度無關）
+func CivilDayLength(date time.Time, loc *time.Location) time.Duration {
+	year, month, day := date.In(loc).Date()
+	start := localMidnight(year, month, day, loc)
+	end := localMidnight(year, month, day+1, loc)
+	return end.Sub(start)
+}
+
+// localMidnight 返回 loc 時區 year-month-day 當天的第一個時刻。
+// 部分時區的日光節約時間在午夜切換，午夜不存在時 time.Date 可能前進至當天的切換時刻，
+// 也可能退回前一天，退回時改用前一天所在時區區段結束（即切換）的時刻
+func localMidnight(year int, month time.Month, day int, loc *time.Location) time.Time {
+	midnight := time.Date(year, month, day, 0, 0, 0, 0, loc)
+	requestedYear, requestedMonth, requestedDay := time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Date()
+	if y, m, d := midnight.Date(); y == requestedYear && m == requestedMonth && d == requestedDay {
+		return midnight
+	}
+	_, transition := midnight.ZoneBounds()
+	return transition
+}
+
+// StartOfDay 返回 t 在 loc 時區所屬日曆日的第一個時刻（UTC），午夜因日光節約時間不存在時返回切換的時刻
+func StartOfDay(t time.Time, loc *time.Location) time.Time {
+	year, month, day := t.In(loc).Date()
+	return localMidnight(year, month, day, loc).UTC()
+}
+
+// EndOfDay 返回 t 在 loc 時區所屬日曆日的最後一個時刻（UTC），即下一天開始前 1 奈秒，
+// 一般為當地 23:59:59.999999999
+func EndOfDay(t time.Time, loc *time.Location) time.Time {
+	year, month, day := t.In(loc).Date()
+	return localMidnight(year, month, day+1, loc).Add(-time.Nanosecond).UTC()
+}
+
+// StartOfWeek 返回 t 在 loc 時區所屬週的第一個時刻（UTC），每週從 weekStart 開始，例如 time.Sunday 或 time.Monday
+func StartOfWeek(t time.Time, loc *time.Location, weekStart time.Weekday) time.Time {
+	local := t.In(loc)
+	offset := (int(local.Weekday()) - int(weekStart) + 7) % 7
+	year, month, day := local.Date()
+	return localMidnight(year, month, day-offset, loc).UTC()
+}
+
+// StartOfMonth 返回 t 在 loc 時區所屬月份的第一個時刻（UTC）
+func StartOfMonth(t time.Time, loc *time.Location) time.Time {
+	year, month, _ := t.In(loc).Date()
+	return localMidnight(year, month, 1, loc).UTC()
+}
+
+// IsLeapYear 依公曆規則判斷閏年：能被 4 整除且不能被 100 整除，或能被 400 整除
+func IsLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// DaysInMonth 返回公曆 year 年 month 月的天數
+func DaysInMonth(year int, month time.Month) int {
+	switch month {
+	case time.February:
+		if IsLeapYear(year) {
+			return 29
+		}
+		return 28
+	case time.April, time.June, time.September, time.November:
+		return 30
+	}
+	return 31
+}
+
+// TimeOfDay 返回 t 在 loc 時區的牆上時鐘時刻，以自午夜起算的時長表示
+// 日光節約時間切換日仍以牆上時鐘計算，例如 09:00 一律返回 9 小時
+func TimeOfDay(t time.Time, loc *time.Location) time.Duration {
+	hour, min, sec := t.In(loc).Clock()
+	return time.Duration(hour)*time.Hour + time.Duration(min)*time.Minute +
+		time.Duration(sec)*time.Second + time.Duration(t.Nanosecond())
+}
+
+// IsWithinTimeOfDayRange 判斷 t 在 loc 時區的時刻是否位於 [start, end) 之間，
+// start 大於 end 時表示跨越午夜的範圍（如 22:00 至 02:00），兩者相等時視為空範圍
+func IsWithinTimeOfDayRange(t time.Time, start, end time.Duration, loc *time.Location) bool {
+	tod := TimeOfDay(t, loc)
+	if start <= end {
+		return tod >= start && tod < end
+	}
+	return tod >= start || tod < end
+}
+
+// DatesInInterval 返回區間 [start, end) 在 loc 時區所涵蓋的每個當地日期，以當地午夜表示，
+// 午夜因日光節約時間不存在時以當天的第一個時刻表示
+// 短於一天但跨越午夜的區間會返回兩個日期；start 等於 end 時返回該時刻所在的日期
+func DatesInInterval(start, end time.Time, loc *time.Location) []time.Time {
+	if end.Before(start) {
+		return nil
+	}
+	year, month, day := start.In(loc).Date()
+	dates := []time.Time{localMidnight(year, month, day, loc)}
+	for {
+		day++
+		next := localMidnight(year, month, day, loc)
+		if !next.Before(end) {
+			return dates
+		}
+		dates = append(dates, next)
+	}
+}
+
+// secondsPerDay 一天的秒數
+const secondsPerDay = 24 * 60 * 60
+
+// EpochDay 返回 t 的UTC日期距 Unix 紀元（1970-01-01）的天數，紀元前為負數
+func EpochDay(t time.Time) int64 {
+	return floorDiv(t.Unix(), secondsPerDay)
+}
+
+// FromEpochDay 返回距 Unix 紀元 day 天的UTC午夜
+func FromEpochDay(day int64) time.Time {
+	return time.Unix(day*secondsPerDay, 0).UTC()
+}
+
+// 試算表（1900 日期系統）序號：1 為 1900-01-01，並沿用 Lotus 1-2-3 將 1900 年視為閏年的錯誤，
+// 序號 60 為不存在的 1900-02-29，因此 1900-03-01 起的序號比實際天數多 1
+const (
+	spreadsheetEpochOffset = 25569
+	spreadsheetLeapBugDay  = 60
+)
+
+// SpreadsheetSerial 返回 t 的UTC日期在試算表 1900 日期系統中的序號
+func SpreadsheetSerial(t time.Time) int64 {
+	serial := EpochDay(t) + spreadsheetEpochOffset
+	if serial <= spreadsheetLeapBugDay {
+		// 1900-03-01 以前的日期不受虛構的 2 月 29 日影響
+		serial--
+	}
+	return serial
+}
+
+// FromSpreadsheetSerial 返回試算表 1900 日期系統序號對應的UTC午夜，
+// 序號小於 1 或為不存在的 1900-02-29（60）時返回錯誤
+func FromSpreadsheetSerial(serial int64) (time.Time, error) {
+	switch {
+	case serial < 1:
+		return time.Time{}, fmt.Errorf("spreadsheet serial %d is before 1900-01-01", serial)
+	case serial == spreadsheetLeapBugDay:
+		return time.Time{}, fmt.Errorf("spreadsheet serial %d is the nonexistent date 1900-02-29", serial)
+	case serial < spreadsheetLeapBugDay:
+		serial++
+	}
+	return FromEpochDay(serial - spreadsheetEpochOffset), nil
+}
+
+// unixEpochJulianDate 1970-01-01T00:00:00Z 的儒略日
+const unixEpochJulianDate = 2440587.5
+
+// ToJulianDate 返回 t 的UTC時刻對應的儒略日（含小數），公式為 JD = Unix秒數 / 86400 + 2440587.5。
+// float64 在目前年代的儒略日約有 40 微秒的精度，且不計閏秒
+func ToJulianDate(t time.Time) float64 {
+	seconds := float64(t.Unix()) + float64(t.Nanosecond())/float64(time.Second)
+	return seconds/secondsPerDay + unixEpochJulianDate
+}
+
+// FromJulianDate 返回儒略日對應的UTC時間，為 ToJulianDate 的反運算，精度限制相同
+func FromJulianDate(jd float64) time.Time {
+	days := jd - unixEpochJulianDate
+	whole := math.Floor(days)
+	nanos := math.Round((days - whole) * secondsPerDay * float64(time.Second))
+	return time.Unix(int64(whole)*secondsPerDay, int64(nanos)).UTC()
+}
+
+// CalendarUnit 日曆對齊單位
+type CalendarUnit int
+
+const (
+	CalendarDay CalendarUnit = iota
+	// 以週一為一週的開始（ISO 8601）
+	CalendarWeek
+	CalendarMonth
+)
+
+// WindowCoverage 一個日曆對齊的視窗及其被時間範圍涵蓋的時長
+type WindowCoverage struct {
+	Start   time.Time
+	End     time.Time
+	Covered time.Duration
+}
+
+// windowStart 返回 t 在 loc 時區所屬日曆視窗的開始時間（loc 時區）
+func windowStart(t time.Time, unit CalendarUnit, loc *time.Location) time.Time {
+	switch unit {
+	case CalendarWeek:
+		return StartOfWeek(t, loc, time.Monday).In(loc)
+	case CalendarMonth:
+		return StartOfMonth(t, loc).In(loc)
+	}
+	return StartOfDay(t, loc).In(loc)
+}
+
+// nextWindowStart 返回 start 所在視窗的下一個視窗開始時間（與 start 相同時區）
+func nextWindowStart(start time.Time, unit CalendarUnit) time.Time {
+	year, month, day := start.Date()
+	loc := start.Location()
+	switch unit {
+	case CalendarWeek:
+		return localMidnight(year, month, day+7, loc)
+	case CalendarMonth:
+		return localMidnight(year, month+1, 1, loc)
+	}
+	return localMidnight(year, month, day+1, loc)
+}
+
+// AlignedWindows 返回與 [start, end) 相交的每個日曆對齊視窗（日、週或月）及其被涵蓋的時長，
+// 頭尾視窗僅計算部分涵蓋
+func AlignedWindows(start, end time.Time, unit CalendarUnit, loc *time.Location) []WindowCoverage {
+	var windows []WindowCoverage
+	for windowBegin := windowStart(start, unit, loc); windowBegin.Before(end); {
+		windowEnd := nextWindowStart(windowBegin, unit)
+		windows = append(windows, WindowCoverage{
+			Start:   windowBegin,
+			End:     windowEnd,
+			Covered: overlapDuration(windowBegin, windowEnd, start, end),
+		})
+		windowBegin = windowEnd
+	}
+	return windows
+}
+
+// CalendarDaysBetween 返回 start 至 end 在 loc 時區跨越的日曆日數，忽略時刻
+// 例如 1 月 1 日 23:00 至 1 月 2 日 01:00 為 1 天；end 早於 start 時返回負數
+func CalendarDaysBetween(start, end time.Time, loc *time.Location) int {
+	// 以UTC午夜比較日期，避免日光節約時間造成的 23 或 25 小時
+	startYear, startMonth, startDay := start.In(loc).Date()
+	endYear, endMonth, endDay := end.In(loc).Date()
+	startDate := time.Date(startYear, startMonth, startDay, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(endYear, endMonth, endDay, 0, 0, 0, 0, time.UTC)
+	return int(EpochDay(endDate) - EpochDay(startDate))
+}
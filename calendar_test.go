@@ -0,0 +1,422 @@
+package timeManagement
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFiscalPeriod(t *testing.T) {
+	location, err := time.LoadLocation("Asia/Taipei")
+	require.NoError(t, err, "Failed to load location")
+
+	tests := []struct {
+		name       string
+		t          time.Time
+		startMonth time.Month
+		fiscalYear int
+		period     int
+	}{
+		{"april start first period", time.Date(2023, 4, 1, 0, 0, 0, 0, location), time.April, 2024, 1},
+		{"april start december", time.Date(2023, 12, 31, 0, 0, 0, 0, location), time.April, 2024, 9},
+		{"april start january", time.Date(2024, 1, 1, 0, 0, 0, 0, location), time.April, 2024, 10},
+		{"april start last period", time.Date(2024, 3, 31, 23, 0, 0, 0, location), time.April, 2024, 12},
+		{"january start", time.Date(2024, 1, 15, 0, 0, 0, 0, location), time.January, 2024, 1},
+		// 2023-03-31 16:30 UTC 在台北已是 4 月 1 日
+		{"utc instant in local april", time.Date(2023, 3, 31, 16, 30, 0, 0, time.UTC), time.April, 2024, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fiscalYear, period := FiscalPeriod(tt.t, tt.startMonth, location)
+			assert.Equal(t, tt.fiscalYear, fiscalYear, "Expected fiscal year to match")
+			assert.Equal(t, tt.period, period, "Expected fiscal period to match")
+		})
+	}
+}
+
+func TestCivilDayLength(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err, "Failed to load location")
+
+	tests := []struct {
+		name     string
+		date     time.Time
+		expected time.Duration
+	}{
+		{"spring forward", time.Date(2023, 3, 12, 12, 0, 0, 0, location), 23 * time.Hour},
+		{"fall back", time.Date(2023, 11, 5, 12, 0, 0, 0, location), 25 * time.Hour},
+		{"normal day", time.Date(2023, 6, 1, 12, 0, 0, 0, location), 24 * time.Hour},
+		// 2023-03-13 02:00 UTC 在紐約仍是 3 月 12 日
+		{"utc instant on local spring forward", time.Date(2023, 3, 13, 2, 0, 0, 0, time.UTC), 23 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, CivilDayLength(tt.date, location), "Expected civil day length to match")
+		})
+	}
+
+	// 哈瓦那 2023-03-12 於午夜切換為夏令時間，當天從 01:00 開始
+	havana, err := time.LoadLocation("America/Havana")
+	require.NoError(t, err, "Failed to load location")
+	assert.Equal(t, 23*time.Hour, CivilDayLength(time.Date(2023, 3, 12, 12, 0, 0, 0, havana), havana), "Expected day with a missing midnight to be 23 hours")
+	assert.Equal(t, 24*time.Hour, CivilDayLength(time.Date(2023, 3, 11, 12, 0, 0, 0, havana), havana), "Expected day before a missing midnight to be 24 hours")
+}
+
+func TestTimeOfDay(t *testing.T) {
+	location, err := time.LoadLocation("Asia/Taipei")
+	require.NoError(t, err, "Failed to load location")
+
+	instant := time.Date(2023, 1, 1, 7, 30, 15, 500, time.UTC)
+	assert.Equal(t, 15*time.Hour+30*time.Minute+15*time.Second+500, TimeOfDay(instant, location), "Expected mid-afternoon time of day in Taipei")
+	assert.Equal(t, 7*time.Hour+30*time.Minute+15*time.Second+500, TimeOfDay(instant, time.UTC), "Expected time of day in UTC")
+}
+
+func TestIsWithinTimeOfDayRange(t *testing.T) {
+	at := func(hour, min int) time.Time {
+		return time.Date(2023, 1, 1, hour, min, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		t        time.Time
+		start    time.Duration
+		end      time.Duration
+		expected bool
+	}{
+		{"within business hours", at(15, 0), 9 * time.Hour, 17 * time.Hour, true},
+		{"end is exclusive", at(17, 0), 9 * time.Hour, 17 * time.Hour, false},
+		{"before business hours", at(8, 59), 9 * time.Hour, 17 * time.Hour, false},
+		{"wrapping late evening", at(23, 0), 22 * time.Hour, 2 * time.Hour, true},
+		{"wrapping early morning", at(1, 30), 22 * time.Hour, 2 * time.Hour, true},
+		{"outside wrapping range", at(12, 0), 22 * time.Hour, 2 * time.Hour, false},
+		{"wrapping end is exclusive", at(2, 0), 22 * time.Hour, 2 * time.Hour, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsWithinTimeOfDayRange(tt.t, tt.start, tt.end, time.UTC), "Expected range check to match")
+		})
+	}
+}
+
+func TestDatesInInterval(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err, "Failed to load location")
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, location)
+	}
+
+	tests := []struct {
+		name     string
+		start    time.Time
+		end      time.Time
+		expected []time.Time
+	}{
+		{"short interval straddling midnight", time.Date(2023, 1, 1, 23, 30, 0, 0, location), time.Date(2023, 1, 2, 0, 30, 0, 0, location), []time.Time{date(2023, 1, 1), date(2023, 1, 2)}},
+		{"multi-day", time.Date(2023, 1, 1, 12, 0, 0, 0, location), time.Date(2023, 1, 4, 1, 0, 0, 0, location), []time.Time{date(2023, 1, 1), date(2023, 1, 2), date(2023, 1, 3), date(2023, 1, 4)}},
+		{"end at midnight is exclusive", time.Date(2023, 1, 1, 12, 0, 0, 0, location), date(2023, 1, 2), []time.Time{date(2023, 1, 1)}},
+		{"across spring forward", time.Date(2023, 3, 11, 22, 0, 0, 0, location), time.Date(2023, 3, 13, 1, 0, 0, 0, location), []time.Time{date(2023, 3, 11), date(2023, 3, 12), date(2023, 3, 13)}},
+		// UTC 當日中午前的區間在紐約屬於前一天晚上至當天
+		{"utc input", time.Date(2023, 1, 2, 3, 0, 0, 0, time.UTC), time.Date(2023, 1, 2, 6, 0, 0, 0, time.UTC), []time.Time{date(2023, 1, 1), date(2023, 1, 2)}},
+		{"zero length", date(2023, 1, 5), date(2023, 1, 5), []time.Time{date(2023, 1, 5)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dates := DatesInInterval(tt.start, tt.end, location)
+			require.Len(t, dates, len(tt.expected), "Expected number of dates to match")
+			for i := range dates {
+				assert.True(t, tt.expected[i].Equal(dates[i]), "Expected date %v, got %v", tt.expected[i], dates[i])
+			}
+		})
+	}
+
+	assert.Nil(t, DatesInInterval(date(2023, 1, 2), date(2023, 1, 1), location), "Expected no dates for a reversed interval")
+
+	// 哈瓦那 2023-03-12 於午夜切換為夏令時間，當天從 01:00 CDT 開始
+	havana, err := time.LoadLocation("America/Havana")
+	require.NoError(t, err, "Failed to load location")
+	dates := DatesInInterval(time.Date(2023, 3, 11, 12, 0, 0, 0, havana), time.Date(2023, 3, 13, 12, 0, 0, 0, havana), havana)
+	require.Len(t, dates, 3, "Expected one entry per local date across the missing midnight")
+	for i, expected := range []time.Time{
+		time.Date(2023, 3, 11, 0, 0, 0, 0, havana),
+		time.Date(2023, 3, 12, 1, 0, 0, 0, havana),
+		time.Date(2023, 3, 13, 0, 0, 0, 0, havana),
+	} {
+		assert.True(t, expected.Equal(dates[i]), "Expected date %v, got %v", expected, dates[i])
+		assert.Equal(t, expected.Day(), dates[i].In(havana).Day(), "Expected entry to fall on its local date")
+	}
+}
+
+func TestEpochDay(t *testing.T) {
+	tests := []struct {
+		t   time.Time
+		day int64
+	}{
+		{time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC), 0},
+		{time.Date(1970, 1, 1, 23, 59, 59, 0, time.UTC), 0},
+		{time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC), 19358},
+		{time.Date(1969, 12, 31, 12, 0, 0, 0, time.UTC), -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.t.String(), func(t *testing.T) {
+			day := EpochDay(tt.t)
+			assert.Equal(t, tt.day, day, "Expected epoch day to match")
+			year, month, date := tt.t.Date()
+			assert.Equal(t, time.Date(year, month, date, 0, 0, 0, 0, time.UTC), FromEpochDay(day), "Expected epoch day to round-trip to UTC midnight")
+		})
+	}
+}
+
+func TestSpreadsheetSerial(t *testing.T) {
+	tests := []struct {
+		t      time.Time
+		serial int64
+	}{
+		{time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC), 1},
+		{time.Date(1900, 2, 28, 0, 0, 0, 0, time.UTC), 59},
+		{time.Date(1900, 3, 1, 0, 0, 0, 0, time.UTC), 61},
+		{time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC), 25569},
+		{time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), 44927},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.t.Format(DateFormat), func(t *testing.T) {
+			assert.Equal(t, tt.serial, SpreadsheetSerial(tt.t), "Expected spreadsheet serial to match")
+			parsed, err := FromSpreadsheetSerial(tt.serial)
+			require.NoError(t, err, "Failed to convert spreadsheet serial")
+			assert.Equal(t, tt.t, parsed, "Expected spreadsheet serial to round-trip")
+		})
+	}
+
+	_, err := FromSpreadsheetSerial(60)
+	assert.Error(t, err, "Expected the nonexistent 1900-02-29 to error")
+	_, err = FromSpreadsheetSerial(0)
+	assert.Error(t, err, "Expected serials before 1900-01-01 to error")
+}
+
+func TestStartOfDayEndOfDay(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err, "Failed to load location")
+
+	// 2023-03-12 為紐約的日光節約時間開始日，02:00 跳至 03:00，當天只有 23 小時
+	t1 := time.Date(2023, 3, 12, 15, 30, 0, 0, newYork)
+	start := StartOfDay(t1, newYork)
+	end := EndOfDay(t1, newYork)
+	assert.Equal(t, time.Date(2023, 3, 12, 5, 0, 0, 0, time.UTC), start, "Expected midnight EST")
+	assert.Equal(t, time.Date(2023, 3, 13, 3, 59, 59, 999999999, time.UTC), end, "Expected 23:59:59.999999999 EDT")
+	assert.Equal(t, 23*time.Hour-time.Nanosecond, end.Sub(start), "Expected a 23-hour day")
+	assert.Equal(t, time.UTC, start.Location(), "Expected UTC time")
+
+	// 聖保羅 2018-11-04 在午夜切換至日光節約時間，當天從 01:00 開始
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err, "Failed to load location")
+	t2 := time.Date(2018, 11, 4, 12, 0, 0, 0, saoPaulo)
+	assert.Equal(t, time.Date(2018, 11, 4, 3, 0, 0, 0, time.UTC), StartOfDay(t2, saoPaulo), "Expected start at the DST transition when midnight does not exist")
+	assert.Equal(t, time.Date(2018, 11, 4, 3, 0, 0, 0, time.UTC).Add(-time.Nanosecond), EndOfDay(t2.AddDate(0, 0, -1), saoPaulo), "Expected previous day to end right before the transition")
+	assert.Equal(t, time.Date(2018, 10, 29, 3, 0, 0, 0, time.UTC), StartOfWeek(t2, saoPaulo, time.Monday), "Expected week start before the transition to be unaffected")
+	assert.Equal(t, time.Date(2018, 11, 4, 3, 0, 0, 0, time.UTC), StartOfWeek(t2, saoPaulo, time.Sunday), "Expected week start at the DST transition")
+
+	// 貝魯特 2023-03-26 在午夜切換至日光節約時間，time.Date 會將不存在的午夜前進至當天 01:00
+	beirut, err := time.LoadLocation("Asia/Beirut")
+	require.NoError(t, err, "Failed to load location")
+	t3 := time.Date(2023, 3, 26, 12, 0, 0, 0, beirut)
+	transition := time.Date(2023, 3, 25, 22, 0, 0, 0, time.UTC)
+	assert.Equal(t, transition, StartOfDay(t3, beirut), "Expected start at the DST transition when midnight is skipped forward")
+	assert.Equal(t, transition.Add(-time.Nanosecond), EndOfDay(t3.AddDate(0, 0, -1), beirut), "Expected previous day to end right before the transition")
+	assert.Equal(t, transition, StartOfWeek(t3, beirut, time.Sunday), "Expected week start at the DST transition")
+}
+
+func TestStartOfWeek(t *testing.T) {
+	location, err := time.LoadLocation("Asia/Taipei")
+	require.NoError(t, err, "Failed to load location")
+
+	tests := []struct {
+		name      string
+		t         time.Time
+		weekStart time.Weekday
+		expected  time.Time
+	}{
+		// 2023-03-02 為週四，所屬週跨越二月與三月
+		{"Monday start across month boundary", time.Date(2023, 3, 2, 10, 0, 0, 0, location), time.Monday, time.Date(2023, 2, 27, 0, 0, 0, 0, location)},
+		{"Sunday start across month boundary", time.Date(2023, 3, 2, 10, 0, 0, 0, location), time.Sunday, time.Date(2023, 2, 26, 0, 0, 0, 0, location)},
+		{"On the week start day", time.Date(2023, 2, 27, 0, 0, 0, 0, location), time.Monday, time.Date(2023, 2, 27, 0, 0, 0, 0, location)},
+		{"Sunday with Monday start", time.Date(2023, 3, 5, 23, 0, 0, 0, location), time.Monday, time.Date(2023, 2, 27, 0, 0, 0, 0, location)},
+		{"Across year boundary", time.Date(2023, 1, 1, 8, 0, 0, 0, location), time.Monday, time.Date(2022, 12, 26, 0, 0, 0, 0, location)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := StartOfWeek(tt.t.UTC(), location, tt.weekStart)
+			assert.Equal(t, tt.expected.UTC(), start, "Expected start of week to match")
+		})
+	}
+}
+
+func TestStartOfMonth(t *testing.T) {
+	location, err := time.LoadLocation("Asia/Taipei")
+	require.NoError(t, err, "Failed to load location")
+
+	// UTC 1 月 31 日 20:00 在台北已是 2 月 1 日
+	t1 := time.Date(2023, 1, 31, 20, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2023, 2, 1, 0, 0, 0, 0, location).UTC(), StartOfMonth(t1, location), "Expected month rollover in the given location")
+	assert.Equal(t, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), StartOfMonth(t1, time.UTC), "Expected start of month in UTC")
+	assert.Equal(t, time.UTC, StartOfMonth(t1, location).Location(), "Expected UTC time")
+}
+
+func TestIsLeapYear(t *testing.T) {
+	tests := []struct {
+		year     int
+		expected bool
+	}{
+		{1900, false},
+		{2000, true},
+		{2023, false},
+		{2024, true},
+		{2100, false},
+		{2400, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.year), func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsLeapYear(tt.year), "Expected leap year result to match")
+		})
+	}
+}
+
+func TestDaysInMonth(t *testing.T) {
+	tests := []struct {
+		year     int
+		month    time.Month
+		expected int
+	}{
+		{1900, time.February, 28},
+		{2000, time.February, 29},
+		{2023, time.February, 28},
+		{2024, time.February, 29},
+		{2023, time.January, 31},
+		{2023, time.April, 30},
+		{2023, time.December, 31},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d-%s", tt.year, tt.month), func(t *testing.T) {
+			assert.Equal(t, tt.expected, DaysInMonth(tt.year, tt.month), "Expected day count to match")
+		})
+	}
+}
+
+func TestJulianDate(t *testing.T) {
+	tests := []struct {
+		name string
+		t    time.Time
+		jd   float64
+	}{
+		{"J2000.0", time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC), 2451545.0},
+		{"Unix epoch", time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC), 2440587.5},
+		{"Quarter day", time.Date(2000, 1, 1, 18, 0, 0, 0, time.UTC), 2451545.25},
+		{"Before Unix epoch", time.Date(1858, 11, 17, 0, 0, 0, 0, time.UTC), 2400000.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.jd, ToJulianDate(tt.t), 1e-9, "Expected Julian Date to match")
+			assert.WithinDuration(t, tt.t, FromJulianDate(tt.jd), time.Millisecond, "Expected time from Julian Date to match")
+		})
+	}
+
+	now := time.Date(2023, 6, 15, 8, 30, 15, 123456789, time.UTC)
+	assert.WithinDuration(t, now, FromJulianDate(ToJulianDate(now)), 100*time.Microsecond, "Expected round trip within precision limits")
+}
+
+func TestAlignedWindowsMonth(t *testing.T) {
+	location, err := time.LoadLocation("Asia/Taipei")
+	require.NoError(t, err, "Failed to load location")
+	day := 24 * time.Hour
+
+	// 1 月 16 日至 3 月 31 日，兩個半月
+	start := time.Date(2023, 1, 16, 0, 0, 0, 0, location)
+	end := time.Date(2023, 3, 31, 0, 0, 0, 0, location)
+	windows := AlignedWindows(start, end, CalendarMonth, location)
+
+	expected := []WindowCoverage{
+		{time.Date(2023, 1, 1, 0, 0, 0, 0, location), time.Date(2023, 2, 1, 0, 0, 0, 0, location), 16 * day},
+		{time.Date(2023, 2, 1, 0, 0, 0, 0, location), time.Date(2023, 3, 1, 0, 0, 0, 0, location), 28 * day},
+		{time.Date(2023, 3, 1, 0, 0, 0, 0, location), time.Date(2023, 4, 1, 0, 0, 0, 0, location), 30 * day},
+	}
+	require.Len(t, windows, len(expected), "Expected one window per month")
+	for i, window := range windows {
+		assert.True(t, expected[i].Start.Equal(window.Start), "Expected window %d start to match", i)
+		assert.True(t, expected[i].End.Equal(window.End), "Expected window %d end to match", i)
+		assert.Equal(t, expected[i].Covered, window.Covered, "Expected window %d coverage to match", i)
+	}
+}
+
+func TestAlignedWindowsWeekAndDay(t *testing.T) {
+	// 2023-01-04 為週三
+	start := time.Date(2023, 1, 4, 12, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 1, 10, 6, 0, 0, 0, time.UTC)
+
+	weeks := AlignedWindows(start, end, CalendarWeek, time.UTC)
+	require.Len(t, weeks, 2, "Expected two weekly windows")
+	assert.Equal(t, time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC), weeks[0].Start, "Expected weeks to start on Monday")
+	assert.Equal(t, 4*24*time.Hour+12*time.Hour, weeks[0].Covered, "Expected partial first week coverage")
+	assert.Equal(t, 24*time.Hour+6*time.Hour, weeks[1].Covered, "Expected partial last week coverage")
+
+	days := AlignedWindows(start, end, CalendarDay, time.UTC)
+	require.Len(t, days, 7, "Expected one window per day")
+	assert.Equal(t, 12*time.Hour, days[0].Covered, "Expected partial first day coverage")
+	assert.Equal(t, 24*time.Hour, days[1].Covered, "Expected full day coverage")
+	assert.Equal(t, 6*time.Hour, days[6].Covered, "Expected partial last day coverage")
+}
+func TestAlignedWindowsMissingMidnight(t *testing.T) {
+	// 聖保羅 2018-11-04 的午夜不存在，當天從 01:00（UTC 03:00）開始，只有 23 小時
+	location, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err, "Failed to load location")
+	transition := time.Date(2018, 11, 4, 3, 0, 0, 0, time.UTC)
+	start := time.Date(2018, 11, 3, 12, 0, 0, 0, location)
+	end := time.Date(2018, 11, 5, 12, 0, 0, 0, location)
+
+	days := AlignedWindows(start, end, CalendarDay, location)
+	require.Len(t, days, 3, "Expected one window per day")
+	assert.True(t, transition.Equal(days[0].End), "Expected the first day to end at the DST transition")
+	assert.True(t, transition.Equal(days[1].Start), "Expected the second day to start at the DST transition")
+	assert.Equal(t, 23*time.Hour, days[1].Covered, "Expected a 23-hour day")
+
+	weeks := AlignedWindows(start, end, CalendarWeek, location)
+	require.Len(t, weeks, 2, "Expected two weekly windows")
+	assert.True(t, weeks[0].End.Equal(StartOfWeek(end, location, time.Monday)), "Expected week boundaries to match StartOfWeek")
+}
+
+func TestCalendarDaysBetween(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err, "Failed to load location")
+
+	tests := []struct {
+		name     string
+		start    time.Time
+		end      time.Time
+		expected int
+	}{
+		{"near midnight", time.Date(2023, 1, 1, 23, 0, 0, 0, location), time.Date(2023, 1, 2, 1, 0, 0, 0, location), 1},
+		{"same day", time.Date(2023, 1, 1, 1, 0, 0, 0, location), time.Date(2023, 1, 1, 23, 0, 0, 0, location), 0},
+		{"across spring forward", time.Date(2023, 3, 11, 12, 0, 0, 0, location), time.Date(2023, 3, 13, 0, 30, 0, 0, location), 2},
+		{"across fall back", time.Date(2023, 11, 4, 23, 30, 0, 0, location), time.Date(2023, 11, 6, 0, 0, 0, 0, location), 2},
+		{"reversed", time.Date(2023, 1, 10, 0, 0, 0, 0, location), time.Date(2023, 1, 1, 23, 0, 0, 0, location), -9},
+		// UTC 時間 2023-01-02 03:00 在紐約仍是 1 月 1 日
+		{"utc input same local day", time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC), time.Date(2023, 1, 2, 3, 0, 0, 0, time.UTC), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, CalendarDaysBetween(tt.start, tt.end, location), "Expected calendar days to match")
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package timeManagement
+
+import (
+	"time"
+)
+
+// Clock 許多第三方函式庫（如 jwt、限流器）所接受的最小時鐘介面
+type Clock interface {
+	Now() time.Time
+}
+
+// stdClock 將 TimeProvider 轉接為 Clock
+type stdClock struct {
+	provider TimeProvider
+}
+
+func (c stdClock) Now() time.Time {
+	return c.provider.Now()
+}
+
+func (r *realTimeProvider) AsStdClock() Clock {
+	return stdClock{provider: r}
+}
+
+// AreSynchronized 判斷兩個 TimeProvider 的當前時間差是否在 tol 以內
+func AreSynchronized(a, b TimeProvider, tol time.Duration) bool {
+	diff := a.Now().Sub(b.Now())
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff <= tol
+}
@@ -0,0 +1,39 @@
+package timeManagement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAsStdClock(t *testing.T) {
+	provider := GetProvider()
+	clock := provider.AsStdClock()
+
+	assert.WithinDuration(t, time.Now().UTC(), clock.Now(), time.Millisecond, "Expected clock to track real time")
+
+	mockTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	provider.SetMockTime(mockTime)
+	defer provider.ClearMockTime()
+	assert.WithinDuration(t, mockTime, clock.Now(), 10*time.Millisecond, "Expected clock to honor mock time")
+
+	provider.SetTimeScale(1000.0)
+	defer provider.ClearTimeScale()
+	time.Sleep(10 * time.Millisecond)
+	assert.True(t, clock.Now().Sub(mockTime) >= 10*time.Second, "Expected clock to honor time scale")
+}
+
+func TestAreSynchronized(t *testing.T) {
+	a := newRealTimeProvider()
+	b := newRealTimeProvider()
+	mockTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	a.SetMockTime(mockTime)
+	b.SetMockTime(mockTime)
+	assert.True(t, AreSynchronized(a, b, 10*time.Millisecond), "Expected providers with the same mock time to be synchronized")
+
+	b.SetMockTime(mockTime.Add(time.Second))
+	assert.False(t, AreSynchronized(a, b, 10*time.Millisecond), "Expected providers with different mock times not to be synchronized")
+	assert.True(t, AreSynchronized(a, b, 2*time.Second), "Expected difference within tolerance to be synchronized")
+}
@@ -0,0 +1,105 @@
+package timeManagement
+
+import (
+	"sync"
+	"time"
+)
+
+// ClockJumpMonitor 背景監測系統時鐘跳動（例如 NTP 調整），
+// 比對相鄰兩次檢查間單調時鐘與牆上時鐘經過的時間，差值超過門檻時通知
+type ClockJumpMonitor struct {
+	interval  time.Duration
+	threshold time.Duration
+	wallClock func() time.Time
+
+	mu        sync.Mutex
+	callbacks []func(delta time.Duration)
+	lastMono  time.Time
+	lastWall  time.Time
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+// NewClockJumpMonitor 建立時鐘跳動監測器，每 interval 檢查一次，跳動超過 threshold 時觸發回調
+func NewClockJumpMonitor(interval, threshold time.Duration) *ClockJumpMonitor {
+	return &ClockJumpMonitor{
+		interval:  interval,
+		threshold: threshold,
+		wallClock: func() time.Time {
+			// 去除單調時鐘讀數，只保留牆上時鐘
+			return time.Now().Round(0)
+		},
+	}
+}
+
+// OnClockJump 註冊時鐘跳動回調，delta 為牆上時鐘相對單調時鐘多走的時間，向後跳為負數
+func (m *ClockJumpMonitor) OnClockJump(f func(delta time.Duration)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.callbacks = append(m.callbacks, f)
+}
+
+// Start 開始背景監測，重複呼叫無效果
+func (m *ClockJumpMonitor) Start() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.stop != nil {
+		return
+	}
+	m.lastMono = time.Now()
+	m.lastWall = m.wallClock()
+	m.stop = make(chan struct{})
+	m.done = make(chan struct{})
+	go m.run(m.stop, m.done)
+}
+
+// Stop 停止背景監測並等待監測協程結束
+func (m *ClockJumpMonitor) Stop() {
+	m.mu.Lock()
+	stop, done := m.stop, m.done
+	m.stop, m.done = nil, nil
+	m.mu.Unlock()
+
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-done
+}
+
+func (m *ClockJumpMonitor) run(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.check()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// check 比對自上次檢查以來兩種時鐘經過的時間，差值超過門檻時觸發回調
+func (m *ClockJumpMonitor) check() {
+	mono := time.Now()
+	wall := m.wallClock()
+
+	m.mu.Lock()
+	delta := wall.Sub(m.lastWall) - mono.Sub(m.lastMono)
+	m.lastMono, m.lastWall = mono, wall
+	callbacks := append([]func(time.Duration){}, m.callbacks...)
+	m.mu.Unlock()
+
+	abs := delta
+	if abs < 0 {
+		abs = -abs
+	}
+	if abs <= m.threshold {
+		return
+	}
+	for _, callback := range callbacks {
+		callback(delta)
+	}
+}
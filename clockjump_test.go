@@ -0,0 +1,64 @@
+package timeManagement
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestClockJumpMonitor 返回牆上時鐘可由測試加上偏移的監測器
+func newTestClockJumpMonitor(interval, threshold time.Duration) (*ClockJumpMonitor, func(time.Duration)) {
+	var mu sync.Mutex
+	var offset time.Duration
+	monitor := NewClockJumpMonitor(interval, threshold)
+	monitor.wallClock = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return time.Now().Round(0).Add(offset)
+	}
+	jump := func(d time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		offset += d
+	}
+	return monitor, jump
+}
+
+func TestClockJumpMonitorDetectsJump(t *testing.T) {
+	monitor, jump := newTestClockJumpMonitor(5*time.Millisecond, time.Second)
+	jumps := make(chan time.Duration, 1)
+	monitor.OnClockJump(func(delta time.Duration) {
+		jumps <- delta
+	})
+
+	monitor.Start()
+	defer monitor.Stop()
+	jump(-time.Minute)
+
+	select {
+	case delta := <-jumps:
+		assert.InDelta(t, float64(-time.Minute), float64(delta), float64(100*time.Millisecond), "Expected callback to report the jump delta")
+	case <-time.After(time.Second):
+		assert.Fail(t, "Expected clock jump callback to fire")
+	}
+}
+
+func TestClockJumpMonitorIgnoresSmallDrift(t *testing.T) {
+	monitor, jump := newTestClockJumpMonitor(time.Hour, time.Second)
+	fired := false
+	monitor.OnClockJump(func(delta time.Duration) {
+		fired = true
+	})
+
+	monitor.Start()
+	monitor.Stop()
+	jump(500 * time.Millisecond)
+	monitor.check()
+	assert.False(t, fired, "Expected drift below threshold to be ignored")
+
+	jump(2 * time.Second)
+	monitor.check()
+	assert.True(t, fired, "Expected jump above threshold to fire")
+}
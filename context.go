@@ -0,0 +1,64 @@
+package timeManagement
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// deadlineContext 由時間提供者計時的截止上下文，Deadline() 返回時間提供者時間軸上的截止時間。
+// Done() 使用自己的通道而非內部 cancelCtx 的通道，衍生的上下文才會經由 Err() 取得 DeadlineExceeded，
+// 而不是直接掛在內部的 cancelCtx 上得到 Canceled；逾時以 cancel cause 記錄，context.Cause 同樣返回 DeadlineExceeded
+type deadlineContext struct {
+	context.Context
+	deadline time.Time
+	done     chan struct{}
+
+	mu sync.Mutex
+	// err 與關閉 done 在同一把鎖內設定，Done() 關閉前 Err() 返回 nil
+	err error
+}
+
+func (c *deadlineContext) Deadline() (time.Time, bool) {
+	return c.deadline, true
+}
+
+func (c *deadlineContext) Done() <-chan struct{} {
+	return c.done
+}
+
+func (c *deadlineContext) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+func (r *realTimeProvider) WithTimeout(parent context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	return r.WithDeadline(parent, r.Now().Add(d))
+}
+
+func (r *realTimeProvider) WithDeadline(parent context.Context, deadline time.Time) (context.Context, context.CancelFunc) {
+	inner, cancel := context.WithCancelCause(parent)
+	ctx := &deadlineContext{
+		Context:  inner,
+		deadline: deadline.UTC(),
+		done:     make(chan struct{}),
+	}
+	timer := r.AfterFunc(r.Until(deadline), func() {
+		cancel(context.DeadlineExceeded)
+	})
+	// 內部上下文結束後再設定錯誤並關閉 Done 通道；因其他原因結束時停止計時器
+	context.AfterFunc(inner, func() {
+		timer.Stop()
+		err := inner.Err()
+		if context.Cause(inner) == context.DeadlineExceeded && err == context.Canceled {
+			// 由計時器取消，內部 cancelCtx 的 Err() 固定為 Canceled
+			err = context.DeadlineExceeded
+		}
+		ctx.mu.Lock()
+		ctx.err = err
+		close(ctx.done)
+		ctx.mu.Unlock()
+	})
+	return ctx, func() { cancel(context.Canceled) }
+}
@@ -0,0 +1,104 @@
+package timeManagement
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithTimeout(t *testing.T) {
+	provider := GetProvider()
+	mockTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	provider.SetMockTime(mockTime)
+	defer provider.ClearMockTime()
+	provider.SetTimeScale(10.0)
+	defer provider.ClearTimeScale()
+
+	start := time.Now()
+	ctx, cancel := provider.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	require.True(t, ok, "Expected context to report a deadline")
+	assert.WithinDuration(t, mockTime.Add(500*time.Millisecond), deadline, 50*time.Millisecond, "Expected deadline on the provider clock")
+
+	select {
+	case <-ctx.Done():
+		assert.Less(t, time.Since(start), 250*time.Millisecond, "Expected context to expire after the scaled interval")
+	case <-time.After(time.Second):
+		require.Fail(t, "Expected context to expire")
+	}
+	assert.ErrorIs(t, ctx.Err(), context.DeadlineExceeded, "Expected deadline exceeded error")
+}
+
+func TestWithDeadlineDerivedContext(t *testing.T) {
+	provider := NewProvider()
+	ctx, cancel := provider.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	child, cancelChild := context.WithCancel(ctx)
+	defer cancelChild()
+	timeoutChild, cancelTimeoutChild := context.WithTimeout(ctx, time.Hour)
+	defer cancelTimeoutChild()
+
+	for _, derived := range []context.Context{child, timeoutChild} {
+		select {
+		case <-derived.Done():
+		case <-time.After(time.Second):
+			require.Fail(t, "Expected derived context to expire with its parent")
+		}
+		assert.ErrorIs(t, derived.Err(), context.DeadlineExceeded, "Expected derived context to report deadline exceeded")
+		assert.ErrorIs(t, context.Cause(derived), context.DeadlineExceeded, "Expected derived context cause to be deadline exceeded")
+	}
+	assert.ErrorIs(t, context.Cause(ctx), context.DeadlineExceeded, "Expected context cause to be deadline exceeded")
+
+	// 取消父上下文時衍生的上下文仍得到 Canceled
+	ctx, cancel = provider.WithTimeout(context.Background(), time.Hour)
+	child, cancelChild = context.WithCancel(ctx)
+	defer cancelChild()
+	cancel()
+	<-child.Done()
+	assert.ErrorIs(t, child.Err(), context.Canceled, "Expected derived context to report cancellation")
+	assert.ErrorIs(t, context.Cause(child), context.Canceled, "Expected derived context cause to be cancellation")
+}
+
+func TestWithDeadlineErrMatchesDone(t *testing.T) {
+	provider := NewProvider()
+	// Err() 非 nil 時 Done() 必須已關閉
+	for i := 0; i < 200; i++ {
+		ctx, cancel := provider.WithTimeout(context.Background(), time.Hour)
+		cancel()
+		if ctx.Err() != nil {
+			select {
+			case <-ctx.Done():
+			default:
+				require.Fail(t, "Expected Done to be closed once Err is non-nil")
+			}
+		}
+		<-ctx.Done()
+		assert.ErrorIs(t, ctx.Err(), context.Canceled, "Expected Err after Done to report cancellation")
+	}
+}
+
+func TestWithDeadlineCancel(t *testing.T) {
+	provider := GetProvider()
+
+	ctx, cancel := provider.WithDeadline(context.Background(), provider.Now().Add(time.Hour))
+	assert.NoError(t, ctx.Err(), "Expected context to be active before the deadline")
+	cancel()
+	<-ctx.Done()
+	assert.ErrorIs(t, ctx.Err(), context.Canceled, "Expected canceled error")
+}
+
+func TestWithDeadlineParentCanceled(t *testing.T) {
+	provider := GetProvider()
+
+	parent, cancelParent := context.WithCancel(context.Background())
+	ctx, cancel := provider.WithDeadline(parent, provider.Now().Add(time.Hour))
+	defer cancel()
+	cancelParent()
+	<-ctx.Done()
+	assert.ErrorIs(t, ctx.Err(), context.Canceled, "Expected parent cancellation to propagate")
+}
@@ -0,0 +1,62 @@
+package timeManagement
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// downtimeWindow 停機時段 [start, end)
+type downtimeWindow struct {
+	start time.Time
+	end   time.Time
+}
+
+// DowntimeSchedule 排定的停機時段清單，重疊或相鄰的時段會合併
+type DowntimeSchedule struct {
+	provider TimeProvider
+
+	mu      sync.Mutex
+	windows []downtimeWindow
+}
+
+func (r *realTimeProvider) NewDowntimeSchedule() *DowntimeSchedule {
+	return &DowntimeSchedule{provider: r}
+}
+
+// Add 新增停機時段 [start, end)，端點顛倒時會先排序
+func (s *DowntimeSchedule) Add(start, end time.Time) {
+	start, end = orderedInterval(start, end)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	windows := append(s.windows, downtimeWindow{start.UTC(), end.UTC()})
+	sort.Slice(windows, func(i, j int) bool {
+		return windows[i].start.Before(windows[j].start)
+	})
+	merged := windows[:1]
+	for _, window := range windows[1:] {
+		last := &merged[len(merged)-1]
+		if window.start.After(last.end) {
+			merged = append(merged, window)
+			continue
+		}
+		if window.end.After(last.end) {
+			last.end = window.end
+		}
+	}
+	s.windows = merged
+}
+
+// InDowntime 判斷當前時間是否位於任一停機時段內，是則一併返回該（合併後）時段的結束時間
+func (s *DowntimeSchedule) InDowntime() (bool, time.Time) {
+	now := s.provider.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, window := range s.windows {
+		if !now.Before(window.start) && now.Before(window.end) {
+			return true, window.end
+		}
+	}
+	return false, time.Time{}
+}
@@ -0,0 +1,45 @@
+package timeManagement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDowntimeSchedule(t *testing.T) {
+	provider := GetProvider()
+	base := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	defer provider.ClearMockTime()
+
+	schedule := provider.NewDowntimeSchedule()
+	// 重疊的 01:00-02:00 與 01:30-03:00，及相鄰的 03:00-04:00
+	schedule.Add(base.Add(time.Hour), base.Add(2*time.Hour))
+	schedule.Add(base.Add(3*time.Hour), base.Add(90*time.Minute))
+	schedule.Add(base.Add(3*time.Hour), base.Add(4*time.Hour))
+	// 獨立的 06:00-07:00
+	schedule.Add(base.Add(6*time.Hour), base.Add(7*time.Hour))
+
+	tests := []struct {
+		name   string
+		now    time.Time
+		active bool
+		end    time.Time
+	}{
+		{"Before any downtime", base.Add(30 * time.Minute), false, time.Time{}},
+		{"In overlapping windows", base.Add(75 * time.Minute), true, base.Add(4 * time.Hour)},
+		{"At adjacent boundary", base.Add(3 * time.Hour), true, base.Add(4 * time.Hour)},
+		{"At merged end", base.Add(4 * time.Hour), false, time.Time{}},
+		{"In separate window", base.Add(6*time.Hour + 30*time.Minute), true, base.Add(7 * time.Hour)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider.SetMockTime(tt.now)
+			provider.FreezeNow()
+			active, end := schedule.InDowntime()
+			assert.Equal(t, tt.active, active, "Expected downtime state to match")
+			assert.Equal(t, tt.end, end, "Expected downtime end to match")
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package timeManagement
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// durationUnits 由大到小排列的時間單位
+var durationUnits = []struct {
+	unit string
+	size time.Duration
+}{
+	{"d", 24 * time.Hour},
+	{"h", time.Hour},
+	{"m", time.Minute},
+	{"s", time.Second},
+	{"ms", time.Millisecond},
+	{"us", time.Microsecond},
+	{"ns", time.Nanosecond},
+}
+
+// DominantUnit 返回數值至少為 1 的最大單位及該單位下的數值，例如 90 分鐘返回 (1.5, "h")
+// 負數依絕對值選擇單位，數值保留正負號；零值返回 (0, "s")
+func DominantUnit(d time.Duration) (float64, string) {
+	if d == 0 {
+		return 0, "s"
+	}
+	abs := d
+	if abs < 0 {
+		abs = -abs
+	}
+	for _, u := range durationUnits {
+		if abs >= u.size {
+			return float64(d) / float64(u.size), u.unit
+		}
+	}
+	return float64(d), "ns"
+}
+
+// PeriodsBetween 返回 start 至 end 之間完整的 period 數量，不足一個週期的部分捨去
+// end 早於 start 時返回負數，其絕對值與參數對調時相同；period 非正數時返回錯誤
+func PeriodsBetween(start, end time.Time, period time.Duration) (int, error) {
+	if period <= 0 {
+		return 0, fmt.Errorf("period must be positive, got %v", period)
+	}
+	return int(end.Sub(start) / period), nil
+}
+
+// niceDurations 由小到大排列的整齊時長：一秒以下為 1、2、5 乘以 10 的次方奈秒，
+// 之後依時間單位取整齊的倍數，一年以上為 1、2、5 乘以 10 的次方年（一年以 365 天計）
+var niceDurations = buildNiceDurations()
+
+func buildNiceDurations() []time.Duration {
+	var durations []time.Duration
+	for magnitude := time.Duration(1); magnitude < time.Second; magnitude *= 10 {
+		durations = append(durations, magnitude, 2*magnitude, 5*magnitude)
+	}
+	for _, seconds := range []time.Duration{1, 2, 5, 10, 15, 30} {
+		durations = append(durations, seconds*time.Second)
+	}
+	for _, minutes := range []time.Duration{1, 2, 5, 10, 15, 30} {
+		durations = append(durations, minutes*time.Minute)
+	}
+	for _, hours := range []time.Duration{1, 2, 3, 6, 12} {
+		durations = append(durations, hours*time.Hour)
+	}
+	for _, days := range []time.Duration{1, 2, 7, 14, 30, 90, 180} {
+		durations = append(durations, days*24*time.Hour)
+	}
+	const year = 365 * 24 * time.Hour
+	for magnitude := time.Duration(1); ; magnitude *= 10 {
+		for _, step := range []time.Duration{1, 2, 5} {
+			if magnitude*step > math.MaxInt64/year {
+				return durations
+			}
+			durations = append(durations, step*magnitude*year)
+		}
+	}
+}
+
+// NiceDuration 將時長對齊至適合時間軸刻度的「整齊」數值，例如 1、2、5、10、15、30 秒或分鐘、
+// 1、2、3、6、12 小時、1、2、7 天等；一秒以下為 1、2、5 乘以 10 的次方奈秒
+// roundUp 為 true 時返回不小於 d 的最小整齊值，否則返回不大於 d 的最大整齊值；d 非正數時返回 0，
+// 超過最大的整齊值（200 年）時返回該值
+func NiceDuration(d time.Duration, roundUp bool) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	i := sort.Search(len(niceDurations), func(i int) bool { return niceDurations[i] >= d })
+	switch {
+	case i == len(niceDurations):
+		return niceDurations[i-1]
+	case niceDurations[i] == d || roundUp:
+		return niceDurations[i]
+	}
+	return niceDurations[i-1]
+}
+
+// ParseClockDuration 解析時鐘格式的時長，支援 "HH:MM:SS" 與 "MM:SS"，秒數可帶小數
+// 第一個欄位不設上限（如 "90:00" 為 90 分鐘），其餘欄位必須小於 60
+func ParseClockDuration(s string) (time.Duration, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid clock duration %q: expected HH:MM:SS or MM:SS", s)
+	}
+
+	secondsPart := parts[len(parts)-1]
+	fraction := ""
+	if i := strings.IndexByte(secondsPart, '.'); i >= 0 {
+		secondsPart, fraction = secondsPart[:i], secondsPart[i+1:]
+		if fraction == "" || len(fraction) > 9 {
+			return 0, fmt.Errorf("invalid clock duration %q: bad fractional seconds", s)
+		}
+	}
+
+	units := []time.Duration{time.Second, time.Minute, time.Hour}
+	parts[len(parts)-1] = secondsPart
+	var total time.Duration
+	for i, field := range parts {
+		value, err := strconv.ParseUint(field, 10, 64)
+		if err != nil || field == "" {
+			return 0, fmt.Errorf("invalid clock duration %q: bad field %q", s, field)
+		}
+		if i > 0 && (len(field) != 2 || value >= 60) {
+			return 0, fmt.Errorf("invalid clock duration %q: field %q must be two digits below 60", s, field)
+		}
+		unit := units[len(parts)-1-i]
+		if value > uint64(math.MaxInt64/unit) || time.Duration(value)*unit > math.MaxInt64-total {
+			return 0, fmt.Errorf("invalid clock duration %q: overflow", s)
+		}
+		total += time.Duration(value) * unit
+	}
+
+	if fraction != "" {
+		nanos, err := strconv.ParseUint(fraction+strings.Repeat("0", 9-len(fraction)), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid clock duration %q: bad fractional seconds", s)
+		}
+		if time.Duration(nanos) > math.MaxInt64-total {
+			return 0, fmt.Errorf("invalid clock duration %q: overflow", s)
+		}
+		total += time.Duration(nanos)
+	}
+	return total, nil
+}
+
+// 擴充時長單位：一天固定為 24 小時（不考慮日光節約時間），一週固定為 7 天
+const (
+	extendedDay  = 24 * time.Hour
+	extendedWeek = 7 * extendedDay
+)
+
+// isDurationNumberByte 判斷是否為時長數值的字元
+func isDurationNumberByte(c byte) bool {
+	return c == '.' || ('0' <= c && c <= '9')
+}
+
+// ParseDurationExtended 解析時長字串，除 time.ParseDuration 的單位外支援 "d"（天，固定 24 小時）
+// 及 "w"（週，固定 7 天），可混合使用，例如 "2w3d4h"、"1.5d"、"-1d12h"
+func ParseDurationExtended(s string) (time.Duration, error) {
+	rest := s
+	negative := false
+	if rest != "" && (rest[0] == '-' || rest[0] == '+') {
+		negative = rest[0] == '-'
+		rest = rest[1:]
+	}
+	if rest == "0" {
+		return 0, nil
+	}
+	if rest == "" {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+
+	var standard strings.Builder
+	var extended float64
+	for rest != "" {
+		i := 0
+		for i < len(rest) && isDurationNumberByte(rest[i]) {
+			i++
+		}
+		j := i
+		for j < len(rest) && !isDurationNumberByte(rest[j]) {
+			j++
+		}
+		number, unit := rest[:i], rest[i:j]
+		if number == "" || unit == "" {
+			return 0, fmt.Errorf("invalid duration %q: expected number followed by unit", s)
+		}
+
+		switch unit {
+		case "d", "w":
+			value, err := strconv.ParseFloat(number, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q: bad number %q", s, number)
+			}
+			size := extendedDay
+			if unit == "w" {
+				size = extendedWeek
+			}
+			extended += value * float64(size)
+		default:
+			standard.WriteString(rest[:j])
+		}
+		rest = rest[j:]
+	}
+
+	var total time.Duration
+	if standard.Len() > 0 {
+		d, err := time.ParseDuration(standard.String())
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %w", s, err)
+		}
+		total = d
+	}
+	if extended+float64(total) >= math.MaxInt64 {
+		return 0, fmt.Errorf("invalid duration %q: overflow", s)
+	}
+	total += time.Duration(extended)
+	if negative {
+		total = -total
+	}
+	return total, nil
+}
@@ -0,0 +1,163 @@
+package timeManagement
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDominantUnit(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		value    float64
+		unit     string
+	}{
+		{90 * time.Minute, 1.5, "h"},
+		{3600 * time.Second, 1, "h"},
+		{36 * time.Hour, 1.5, "d"},
+		{10 * 24 * time.Hour, 10, "d"},
+		{1500 * time.Millisecond, 1.5, "s"},
+		{250 * time.Millisecond, 250, "ms"},
+		{2 * time.Microsecond, 2, "us"},
+		{500 * time.Nanosecond, 500, "ns"},
+		{-90 * time.Second, -1.5, "m"},
+		{0, 0, "s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.duration.String(), func(t *testing.T) {
+			value, unit := DominantUnit(tt.duration)
+			assert.Equal(t, tt.unit, unit, "Expected unit to match")
+			assert.InDelta(t, tt.value, value, 1e-9, "Expected value to match")
+		})
+	}
+}
+
+func TestPeriodsBetween(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	period := 30 * 24 * time.Hour
+
+	tests := []struct {
+		name     string
+		end      time.Time
+		expected int
+	}{
+		{"exact multiple", start.Add(3 * period), 3},
+		{"partial trailing period", start.Add(2*period + 29*24*time.Hour), 2},
+		{"less than one period", start.Add(time.Hour), 0},
+		{"same instant", start, 0},
+		{"reversed", start.Add(-2*period - time.Hour), -2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count, err := PeriodsBetween(start, tt.end, period)
+			require.NoError(t, err, "Failed to count periods")
+			assert.Equal(t, tt.expected, count, "Expected period count to match")
+		})
+	}
+
+	_, err := PeriodsBetween(start, start.Add(time.Hour), 0)
+	assert.Error(t, err, "Expected non-positive period to error")
+}
+
+func TestNiceDuration(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		up       time.Duration
+		down     time.Duration
+	}{
+		{time.Second, time.Second, time.Second},
+		{1300 * time.Millisecond, 2 * time.Second, time.Second},
+		{3 * time.Second, 5 * time.Second, 2 * time.Second},
+		{7 * time.Second, 10 * time.Second, 5 * time.Second},
+		{20 * time.Second, 30 * time.Second, 15 * time.Second},
+		{45 * time.Second, time.Minute, 30 * time.Second},
+		{150 * time.Millisecond, 200 * time.Millisecond, 100 * time.Millisecond},
+		{3 * time.Nanosecond, 5 * time.Nanosecond, 2 * time.Nanosecond},
+		{40 * time.Minute, time.Hour, 30 * time.Minute},
+		{time.Hour, time.Hour, time.Hour},
+		{4 * time.Hour, 6 * time.Hour, 3 * time.Hour},
+		{13 * time.Hour, 24 * time.Hour, 12 * time.Hour},
+		{3 * 24 * time.Hour, 7 * 24 * time.Hour, 2 * 24 * time.Hour},
+		{400 * 24 * time.Hour, 2 * 365 * 24 * time.Hour, 365 * 24 * time.Hour},
+		{0, 0, 0},
+		{math.MaxInt64, 200 * 365 * 24 * time.Hour, 200 * 365 * 24 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.duration.String(), func(t *testing.T) {
+			assert.Equal(t, tt.up, NiceDuration(tt.duration, true), "Expected rounded up value to match")
+			assert.Equal(t, tt.down, NiceDuration(tt.duration, false), "Expected rounded down value to match")
+		})
+	}
+}
+
+func TestParseClockDuration(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{"01:30:00", 90 * time.Minute},
+		{"90:00", 90 * time.Minute},
+		{"00:00:00.5", 500 * time.Millisecond},
+		{"100:00:01", 100*time.Hour + time.Second},
+		{"02:03.000000001", 2*time.Minute + 3*time.Second + time.Nanosecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			d, err := ParseClockDuration(tt.value)
+			require.NoError(t, err, "Failed to parse clock duration")
+			assert.Equal(t, tt.expected, d, "Expected duration to match")
+		})
+	}
+
+	for _, invalid := range []string{"", "90", "1:2:3:4", "01:60:00", "01:5:00", "aa:00", "00:00.", "-01:00", "00:00:00.1234567890"} {
+		_, err := ParseClockDuration(invalid)
+		assert.Error(t, err, "Expected %q to be rejected", invalid)
+	}
+
+	// time.Duration 最多約 2562047 小時
+	for _, overflow := range []string{"4000000:00:00", "2562048:00:00", "2562047:47:17", "2562047:47:16.854775808", "99999999999999999999:00"} {
+		_, err := ParseClockDuration(overflow)
+		assert.Error(t, err, "Expected %q to be rejected as out of range", overflow)
+	}
+	d, err := ParseClockDuration("2562047:47:16.854775807")
+	require.NoError(t, err, "Failed to parse the largest clock duration")
+	assert.Equal(t, time.Duration(math.MaxInt64), d, "Expected the largest representable duration")
+}
+
+func TestParseDurationExtended(t *testing.T) {
+	day := 24 * time.Hour
+	tests := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{"1d", day},
+		{"2w", 14 * day},
+		{"2w3d4h", 17*day + 4*time.Hour},
+		{"1d12h30m", day + 12*time.Hour + 30*time.Minute},
+		{"1.5d", 36 * time.Hour},
+		{"-1d1h", -(day + time.Hour)},
+		{"1h30m", 90 * time.Minute},
+		{"500ms", 500 * time.Millisecond},
+		{"0", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			d, err := ParseDurationExtended(tt.value)
+			require.NoError(t, err, "Failed to parse extended duration")
+			assert.Equal(t, tt.expected, d, "Expected duration to match")
+		})
+	}
+
+	for _, invalid := range []string{"", "-", "5", "d", "1x", "1d-2h", "1..5d", "100000w"} {
+		_, err := ParseDurationExtended(invalid)
+		assert.Error(t, err, "Expected %q to be rejected", invalid)
+	}
+}
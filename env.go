@@ -0,0 +1,105 @@
+package timeManagement
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 傳遞時鐘設定給子行程的環境變數名稱
+const (
+	EnvMockTime  = "TIMEMANAGEMENT_MOCK_TIME"
+	EnvTimeScale = "TIMEMANAGEMENT_TIME_SCALE"
+	EnvOffset    = "TIMEMANAGEMENT_OFFSET"
+	EnvFrozen    = "TIMEMANAGEMENT_FROZEN"
+)
+
+func (r *realTimeProvider) EnvFromState() []string {
+	now := r.Now()
+	realNow := time.Now().UTC()
+
+	r.mockTimeLock.RLock()
+	mocked := r.mockTime != nil
+	// 凍結或逐步推進的模擬時間及暫停中的時鐘皆不隨真實時間前進，子行程以凍結的時間延續
+	frozen := (mocked && r.mockFrozen) || r.paused
+	scale := r.timeScale
+	r.mockTimeLock.RUnlock()
+
+	var env []string
+	if mocked || frozen {
+		env = append(env, EnvMockTime+"="+now.Format(time.RFC3339Nano))
+	}
+	if frozen {
+		env = append(env, EnvFrozen+"=true")
+	}
+	if scale != 1.0 {
+		env = append(env, EnvTimeScale+"="+strconv.FormatFloat(scale, 'g', -1, 64))
+		if !mocked && !frozen {
+			// 時間加速會使時鐘領先或落後真實時間，子行程需從相同的時刻繼續
+			env = append(env, EnvOffset+"="+now.Sub(realNow).String())
+		}
+	}
+	return env
+}
+
+// NewProviderFromEnv 依 EnvFromState 產生的環境變數（例如 os.Environ()）建立獨立的 TimeProvider
+func NewProviderFromEnv(environ []string) (TimeProvider, error) {
+	values := make(map[string]string)
+	for _, kv := range environ {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case EnvMockTime, EnvTimeScale, EnvOffset, EnvFrozen:
+			values[key] = value
+		}
+	}
+
+	frozen := false
+	if value, ok := values[EnvFrozen]; ok {
+		var err error
+		if frozen, err = strconv.ParseBool(value); err != nil {
+			return nil, fmt.Errorf("invalid %s: %q", EnvFrozen, value)
+		}
+	}
+
+	provider := newRealTimeProvider()
+	value, ok := values[EnvMockTime]
+	switch {
+	case ok:
+		mockTime, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", EnvMockTime, err)
+		}
+		if frozen {
+			provider.SetFrozenTime(mockTime)
+		} else {
+			provider.SetMockTime(mockTime)
+		}
+	case frozen:
+		return nil, fmt.Errorf("invalid %s: requires %s", EnvFrozen, EnvMockTime)
+	}
+
+	if value, ok := values[EnvTimeScale]; ok {
+		scale, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %q", EnvTimeScale, value)
+		}
+		if err := provider.SetTimeScale(scale); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", EnvTimeScale, err)
+		}
+	}
+
+	if value, ok := values[EnvOffset]; ok {
+		offset, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", EnvOffset, err)
+		}
+		provider.mockTimeLock.Lock()
+		provider.baseTime = provider.baseTime.Add(offset)
+		provider.mockTimeLock.Unlock()
+	}
+	return provider, nil
+}
@@ -0,0 +1,96 @@
+package timeManagement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvFromStateMockTime(t *testing.T) {
+	parent := newRealTimeProvider()
+	parent.SetMockTime(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	parent.SetTimeScale(2.0)
+
+	env := parent.EnvFromState()
+	assert.Len(t, env, 2, "Expected mock time and scale to be encoded")
+
+	child, err := NewProviderFromEnv(env)
+	require.NoError(t, err, "Failed to construct provider from env")
+	assert.Equal(t, 2.0, child.GetTimeScale(), "Expected time scale to match")
+	assert.WithinDuration(t, parent.Now(), child.Now(), 20*time.Millisecond, "Expected child clock to match parent")
+}
+
+func TestEnvFromStateFrozen(t *testing.T) {
+	frozen := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	parents := map[string]func(p *realTimeProvider){
+		"frozen": func(p *realTimeProvider) { p.SetFrozenTime(frozen) },
+		"stepped": func(p *realTimeProvider) {
+			p.SetMockTime(frozen.Add(-time.Hour))
+			p.AdvanceMockTime(time.Hour)
+		},
+		"paused": func(p *realTimeProvider) {
+			p.SetMockTime(frozen)
+			p.Pause()
+		},
+	}
+	for name, setup := range parents {
+		t.Run(name, func(t *testing.T) {
+			parent := newRealTimeProvider()
+			setup(parent)
+
+			env := parent.EnvFromState()
+			assert.Contains(t, env, EnvFrozen+"=true", "Expected frozen clock to be encoded")
+			child, err := NewProviderFromEnv(env)
+			require.NoError(t, err, "Failed to construct provider from env")
+			time.Sleep(10 * time.Millisecond)
+			assert.Equal(t, parent.Now(), child.Now(), "Expected child clock to stay frozen at the parent's time")
+
+			// 子行程同樣以逐步模式推進
+			child.AdvanceMockTime(time.Minute)
+			assert.Equal(t, parent.Now().Add(time.Minute), child.Now(), "Expected child clock to step exactly")
+		})
+	}
+
+	// 未模擬的時鐘暫停時以凍結時間傳遞
+	parent := newRealTimeProvider()
+	parent.Pause()
+	child, err := NewProviderFromEnv(parent.EnvFromState())
+	require.NoError(t, err, "Failed to construct provider from env")
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, parent.Now(), child.Now(), "Expected child clock to stay at the paused time")
+}
+
+func TestEnvFromStateScaled(t *testing.T) {
+	parent := newRealTimeProvider()
+	parent.SetTimeScale(1000.0)
+	time.Sleep(10 * time.Millisecond)
+
+	child, err := NewProviderFromEnv(append([]string{"PATH=/usr/bin"}, parent.EnvFromState()...))
+	require.NoError(t, err, "Failed to construct provider from env")
+	assert.Equal(t, 1000.0, child.GetTimeScale(), "Expected time scale to match")
+	assert.WithinDuration(t, parent.Now(), child.Now(), time.Second, "Expected child clock to continue from the parent's scaled time")
+}
+
+func TestEnvFromStateDefault(t *testing.T) {
+	parent := newRealTimeProvider()
+	assert.Empty(t, parent.EnvFromState(), "Expected no env vars for default state")
+
+	child, err := NewProviderFromEnv(nil)
+	require.NoError(t, err, "Failed to construct provider from empty env")
+	assert.WithinDuration(t, time.Now().UTC(), child.Now(), time.Millisecond, "Expected child to track real time")
+}
+
+func TestNewProviderFromEnvInvalid(t *testing.T) {
+	for _, env := range [][]string{
+		{EnvMockTime + "=yesterday"},
+		{EnvTimeScale + "=-1"},
+		{EnvTimeScale + "=2", EnvOffset + "=soon"},
+		{EnvMockTime + "=2023-01-01T12:00:00Z", EnvFrozen + "=maybe"},
+		{EnvFrozen + "=true"},
+	} {
+		_, err := NewProviderFromEnv(env)
+		assert.Error(t, err, "Expected %v to be rejected", env)
+	}
+}
@@ -0,0 +1,63 @@
+package timeManagement
+
+import (
+	"math"
+	"time"
+)
+
+func (r *realTimeProvider) ProjectCrossing(current, target, ratePerSecond float64) (time.Time, bool) {
+	now := r.Now()
+	if current == target {
+		return now, true
+	}
+	seconds := (target - current) / ratePerSecond
+	if ratePerSecond == 0 || seconds < 0 || math.IsNaN(seconds) || seconds*float64(time.Second) > math.MaxInt64 {
+		return time.Time{}, false
+	}
+	return now.Add(time.Duration(seconds * float64(time.Second))), true
+}
+
+func (r *realTimeProvider) Freshness(t time.Time, maxAge time.Duration) (time.Duration, bool) {
+	age := r.Since(t)
+	return age, age <= maxAge
+}
+
+func (r *realTimeProvider) Lateness(scheduled time.Time) time.Duration {
+	if late := r.Since(scheduled); late > 0 {
+		return late
+	}
+	return 0
+}
+
+func (r *realTimeProvider) MidpointToDeadline(deadline time.Time) time.Time {
+	now := r.Now()
+	remaining := deadline.Sub(now)
+	if remaining <= 0 {
+		return now
+	}
+	return now.Add(remaining / 2)
+}
+
+// NextAvailable 返回距上次呼叫 minInterval 後可再次呼叫的時間
+func NextAvailable(lastCall time.Time, minInterval time.Duration) time.Time {
+	return lastCall.Add(minInterval).UTC()
+}
+
+func (r *realTimeProvider) DurationUntilAvailable(lastCall time.Time, minInterval time.Duration) time.Duration {
+	if wait := r.Until(NextAvailable(lastCall, minInterval)); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
+func (r *realTimeProvider) EstimateCompletion(start time.Time, fractionDone float64) (time.Time, bool) {
+	if fractionDone <= 0 || math.IsNaN(fractionDone) {
+		return time.Time{}, false
+	}
+	elapsed := r.Since(start)
+	total := float64(elapsed) / fractionDone
+	if total > math.MaxInt64 {
+		return time.Time{}, false
+	}
+	return start.Add(time.Duration(total)).UTC(), true
+}
@@ -0,0 +1,131 @@
+package timeManagement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectCrossing(t *testing.T) {
+	provider := GetProvider()
+	mockTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	provider.SetMockTime(mockTime)
+	defer provider.ClearMockTime()
+
+	tests := []struct {
+		name      string
+		current   float64
+		target    float64
+		rate      float64
+		reachable bool
+		after     time.Duration
+	}{
+		{"positive rate", 10, 70, 2, true, 30 * time.Second},
+		{"negative rate", 100, 40, -0.5, true, 2 * time.Minute},
+		{"already at target", 5, 5, 0, true, 0},
+		{"moving away", 10, 70, -2, false, 0},
+		{"zero rate", 10, 70, 0, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			crossing, ok := provider.ProjectCrossing(tt.current, tt.target, tt.rate)
+			assert.Equal(t, tt.reachable, ok, "Expected reachability to match")
+			if tt.reachable {
+				assert.WithinDuration(t, mockTime.Add(tt.after), crossing, 10*time.Millisecond, "Expected projected crossing time to match")
+			}
+		})
+	}
+}
+
+func TestFreshness(t *testing.T) {
+	provider := GetProvider()
+	mockTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	provider.SetMockTime(mockTime)
+	defer provider.ClearMockTime()
+
+	tests := []struct {
+		name  string
+		t     time.Time
+		age   time.Duration
+		fresh bool
+	}{
+		{"fresh", mockTime.Add(-30 * time.Second), 30 * time.Second, true},
+		{"stale", mockTime.Add(-5 * time.Minute), 5 * time.Minute, false},
+		{"future", mockTime.Add(time.Minute), -time.Minute, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			age, fresh := provider.Freshness(tt.t, time.Minute)
+			assert.InDelta(t, float64(tt.age), float64(age), float64(10*time.Millisecond), "Expected age to match")
+			assert.Equal(t, tt.fresh, fresh, "Expected freshness verdict to match")
+		})
+	}
+}
+
+func TestLateness(t *testing.T) {
+	provider := GetProvider()
+	mockTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	provider.SetMockTime(mockTime)
+	defer provider.ClearMockTime()
+
+	assert.InDelta(t, 0, float64(provider.Lateness(mockTime)), float64(10*time.Millisecond), "Expected on-time job to have no lateness")
+	assert.InDelta(t, float64(5*time.Minute), float64(provider.Lateness(mockTime.Add(-5*time.Minute))), float64(10*time.Millisecond), "Expected late job lateness to match")
+	assert.Zero(t, provider.Lateness(mockTime.Add(time.Minute)), "Expected not-yet-due job to have zero lateness")
+}
+
+func TestMidpointToDeadline(t *testing.T) {
+	provider := GetProvider()
+	mockTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	provider.SetMockTime(mockTime)
+	defer provider.ClearMockTime()
+
+	midpoint := provider.MidpointToDeadline(mockTime.Add(10 * time.Minute))
+	assert.WithinDuration(t, mockTime.Add(5*time.Minute), midpoint, 10*time.Millisecond, "Expected midpoint halfway to the deadline")
+
+	past := provider.MidpointToDeadline(mockTime.Add(-time.Minute))
+	assert.WithinDuration(t, mockTime, past, 10*time.Millisecond, "Expected past deadline to return Now()")
+}
+
+func TestNextAvailable(t *testing.T) {
+	lastCall := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2023, 1, 1, 12, 0, 30, 0, time.UTC), NextAvailable(lastCall, 30*time.Second), "Expected next available time to match")
+}
+
+func TestDurationUntilAvailable(t *testing.T) {
+	provider := GetProvider()
+	mockTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	provider.SetMockTime(mockTime)
+	defer provider.ClearMockTime()
+
+	wait := provider.DurationUntilAvailable(mockTime.Add(-10*time.Second), 30*time.Second)
+	assert.InDelta(t, float64(20*time.Second), float64(wait), float64(10*time.Millisecond), "Expected a recent call to require waiting")
+	assert.Zero(t, provider.DurationUntilAvailable(mockTime.Add(-time.Minute), 30*time.Second), "Expected an old call to be available now")
+}
+
+func TestEstimateCompletion(t *testing.T) {
+	provider := GetProvider()
+	start := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	provider.SetMockTime(start.Add(time.Hour))
+	defer provider.ClearMockTime()
+
+	tests := []struct {
+		fraction float64
+		expected time.Time
+	}{
+		{0.25, start.Add(4 * time.Hour)},
+		{0.5, start.Add(2 * time.Hour)},
+		{1, start.Add(time.Hour)},
+	}
+
+	for _, tt := range tests {
+		completion, ok := provider.EstimateCompletion(start, tt.fraction)
+		assert.True(t, ok, "Expected completion estimate for fraction %v", tt.fraction)
+		assert.WithinDuration(t, tt.expected, completion, 50*time.Millisecond, "Expected projected completion for fraction %v", tt.fraction)
+	}
+
+	_, ok := provider.EstimateCompletion(start, 0)
+	assert.False(t, ok, "Expected no estimate without progress")
+}
@@ -0,0 +1,147 @@
+package timeManagement
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func (r *realTimeProvider) FormatEmailDate(t time.Time, loc *time.Location) string {
+	return t.In(loc).Format(time.RFC1123Z)
+}
+
+// ParseEmailDate 以 net/mail 解析，接受 RFC 5322 允許的省略星期、省略秒數、以 GMT 或 UT 表示的時區及結尾的註解（如 "(PST)"）
+func (r *realTimeProvider) ParseEmailDate(value string) (time.Time, error) {
+	t, err := mail.ParseDate(value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.UTC(), nil
+}
+
+// parseAnyLayouts ParseAny 依序嘗試的版面，全部嘗試失敗後才嘗試 Unix 時間戳
+var parseAnyLayouts = []string{
+	DateFormat,
+	TimeFormat,
+	DateTimeFormat,
+	DateTimeFormatTZ,
+	DateTimeFormatMilli,
+	time.RFC3339,
+	time.RFC3339Nano,
+}
+
+// ParseAny 依序以套件已知的版面（DateFormat、TimeFormat、DateTimeFormat、DateTimeFormatTZ、
+// DateTimeFormatMilli、RFC 3339）及 Unix 秒數時間戳解析 value，返回第一個成功的結果（UTC），
+// 不含時區的版面視為UTC；全部失敗時錯誤會列出嘗試過的版面
+func ParseAny(value string) (time.Time, error) {
+	if t, err := ParseWithLayouts(value, parseAnyLayouts); err == nil {
+		return t, nil
+	}
+	if sec, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(sec, 0).UTC(), nil
+	}
+	return time.Time{}, fmt.Errorf("cannot parse %q: tried layouts %q and unix epoch seconds", value, parseAnyLayouts)
+}
+
+// ParseWithLayouts 依 layouts 的順序解析 value，返回第一個成功的結果（UTC），不含時區的版面視為UTC；
+// 有歧義的輸入（如 "01/02/2023"）會以排在前面的版面解讀，全部失敗時錯誤會列出嘗試過的版面
+func ParseWithLayouts(value string, layouts []string) (time.Time, error) {
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("cannot parse %q: tried layouts %q", value, layouts)
+}
+
+// layoutProbeTime 用於偵測版面是否含有參考時間元件，各欄位皆與參考時間不同
+var layoutProbeTime = time.Date(2017, 11, 28, 22, 53, 49, 123456789, time.FixedZone("PRB", 3*3600))
+
+// ValidateLayout 檢查版面是否為可辨識的 Go 參考時間版面（至少含有一個參考時間元件）
+func ValidateLayout(layout string) error {
+	if strings.TrimSpace(layout) == "" {
+		return errors.New("layout must not be empty")
+	}
+	if strings.Contains(layout, "%") {
+		return fmt.Errorf("layout %q looks like a strftime pattern, use Go reference time layout instead", layout)
+	}
+	if layoutProbeTime.Format(layout) == layout {
+		return fmt.Errorf("layout %q contains no reference time component", layout)
+	}
+	return nil
+}
+
+// CanonicalizeLayout 對版面做小幅正規化後驗證：
+// 去除前後空白、合併連續空白，並將結尾的字面 "Z" 改為可解析時區偏移的 "Z07:00"
+func CanonicalizeLayout(layout string) (string, error) {
+	canonical := strings.Join(strings.Fields(layout), " ")
+	if strings.HasSuffix(canonical, "05Z") {
+		canonical += "07:00"
+	}
+	if err := ValidateLayout(canonical); err != nil {
+		return "", err
+	}
+	return canonical, nil
+}
+
+// SortableKeyFormat 固定寬度、字典序與時間先後一致的鍵格式（適用於 0000 至 9999 年）
+const SortableKeyFormat = "20060102T150405.000000000Z"
+
+func (r *realTimeProvider) SortableKey(t time.Time) string {
+	return t.UTC().Format(SortableKeyFormat)
+}
+
+func (r *realTimeProvider) ParseSortableKey(key string) (time.Time, error) {
+	// 鍵的結尾 Z 為字面字元，一律以UTC解析
+	return r.ParseInLocation(SortableKeyFormat, key, time.UTC)
+}
+
+// compactSize 緊湊編碼的固定長度
+const compactSize = 8
+
+// EncodeCompact 將時間編碼為固定 8 位元組的大端序 UnixNano，並翻轉符號位使位元組順序與時間先後一致
+// 僅支援 UnixNano 可表示的範圍（約 1678 至 2262 年）
+func EncodeCompact(t time.Time) []byte {
+	b := make([]byte, compactSize)
+	binary.BigEndian.PutUint64(b, uint64(t.UnixNano())^(1<<63))
+	return b
+}
+
+// DecodeCompact 解碼 EncodeCompact 產生的位元組，返回UTC時間
+func DecodeCompact(b []byte) (time.Time, error) {
+	if len(b) != compactSize {
+		return time.Time{}, fmt.Errorf("compact time must be %d bytes, got %d", compactSize, len(b))
+	}
+	return time.Unix(0, int64(binary.BigEndian.Uint64(b)^(1<<63))).UTC(), nil
+}
+
+func (r *realTimeProvider) ParseRange(s string, layout string, loc *time.Location) (time.Time, time.Time, error) {
+	// 版面本身可能含有 "/"，依序嘗試每個分隔位置直到兩端皆可解析
+	var err error
+	for i := strings.IndexByte(s, '/'); i >= 0; {
+		var start, end time.Time
+		start, err = r.ParseInLocation(layout, s[:i], loc)
+		if err == nil {
+			end, err = r.ParseInLocation(layout, s[i+1:], loc)
+			if err == nil {
+				if start.After(end) {
+					return time.Time{}, time.Time{}, fmt.Errorf("range %q starts after it ends", s)
+				}
+				return start, end, nil
+			}
+		}
+		next := strings.IndexByte(s[i+1:], '/')
+		if next < 0 {
+			break
+		}
+		i += next + 1
+	}
+	if err == nil {
+		err = errors.New("missing \"/\" separator")
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("invalid range %q: %w", s, err)
+}
@@ -0,0 +1,235 @@
+package timeManagement
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatEmailDate(t *testing.T) {
+	provider := GetProvider()
+	location, err := time.LoadLocation("Asia/Taipei")
+	require.NoError(t, err, "Failed to load location")
+	instant := time.Date(2023, 1, 2, 4, 5, 6, 0, time.UTC)
+	formatted := provider.FormatEmailDate(instant, location)
+	assert.Equal(t, "Mon, 02 Jan 2023 12:05:06 +0800", formatted, "Expected RFC 2822 date in Asia/Taipei")
+
+	parsed, err := provider.ParseEmailDate(formatted)
+	require.NoError(t, err, "Failed to parse email date")
+	assert.True(t, parsed.Equal(instant), "Expected round-trip to match original instant")
+	assert.Equal(t, time.UTC, parsed.Location(), "Expected parsed time to be UTC")
+}
+
+func TestParseEmailDate(t *testing.T) {
+	provider := GetProvider()
+	parsed, err := provider.ParseEmailDate("Mon, 2 Jan 2023 12:05:06 +0800")
+	require.NoError(t, err, "Failed to parse single-digit day")
+	assert.True(t, parsed.Equal(time.Date(2023, 1, 2, 4, 5, 6, 0, time.UTC)), "Expected parsed time to match")
+
+	// RFC 5322 允許省略星期、過時的時區名稱及結尾的註解
+	tests := []struct {
+		value    string
+		expected time.Time
+	}{
+		{"2 Jan 2006 15:04:05 -0700", time.Date(2006, 1, 2, 22, 4, 5, 0, time.UTC)},
+		{"Mon, 2 Jan 2006 15:04:05 GMT", time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{"Mon, 2 Jan 2006 15:04:05 -0800 (PST)", time.Date(2006, 1, 2, 23, 4, 5, 0, time.UTC)},
+		{"Mon, 2 Jan 2006 15:04 +0000", time.Date(2006, 1, 2, 15, 4, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		parsed, err := provider.ParseEmailDate(tt.value)
+		require.NoError(t, err, "Failed to parse %q", tt.value)
+		assert.Equal(t, tt.expected, parsed, "Expected %q to match", tt.value)
+	}
+
+	_, err = provider.ParseEmailDate("2023-01-02 12:05:06")
+	assert.Error(t, err, "Expected error for non RFC 2822 input")
+}
+
+func TestParseAny(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Time
+	}{
+		{"2023-01-02", time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"15:04:05", time.Date(0, 1, 1, 15, 4, 5, 0, time.UTC)},
+		{"2023-01-02 15:04:05", time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{"2023-01-02T15:04:05+08:00", time.Date(2023, 1, 2, 7, 4, 5, 0, time.UTC)},
+		{"2023-01-02 15:04:05.123", time.Date(2023, 1, 2, 15, 4, 5, 123000000, time.UTC)},
+		{"2023-01-02T15:04:05.123456789Z", time.Date(2023, 1, 2, 15, 4, 5, 123456789, time.UTC)},
+		{"1672574400", time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			parsed, err := ParseAny(tt.value)
+			require.NoError(t, err, "Failed to parse %q", tt.value)
+			assert.Equal(t, tt.expected, parsed, "Expected parsed time to match")
+			assert.Equal(t, time.UTC, parsed.Location(), "Expected UTC time")
+		})
+	}
+
+	_, err := ParseAny("01/02/2023")
+	require.Error(t, err, "Expected unknown format to be rejected")
+	assert.Contains(t, err.Error(), DateTimeFormatMilli, "Expected error to list attempted layouts")
+	assert.Contains(t, err.Error(), "unix", "Expected error to mention unix epoch")
+}
+
+func TestParseWithLayouts(t *testing.T) {
+	usFirst := []string{"01/02/2006", "02/01/2006"}
+	euFirst := []string{"02/01/2006", "01/02/2006"}
+
+	parsed, err := ParseWithLayouts("03/04/2023", usFirst)
+	require.NoError(t, err, "Failed to parse with US-first layouts")
+	assert.Equal(t, time.Date(2023, 3, 4, 0, 0, 0, 0, time.UTC), parsed, "Expected month-first interpretation")
+
+	parsed, err = ParseWithLayouts("03/04/2023", euFirst)
+	require.NoError(t, err, "Failed to parse with EU-first layouts")
+	assert.Equal(t, time.Date(2023, 4, 3, 0, 0, 0, 0, time.UTC), parsed, "Expected day-first interpretation")
+
+	// 13 不是有效月份，只有日在前的版面能解析
+	parsed, err = ParseWithLayouts("13/04/2023", usFirst)
+	require.NoError(t, err, "Failed to fall back to the second layout")
+	assert.Equal(t, time.Date(2023, 4, 13, 0, 0, 0, 0, time.UTC), parsed, "Expected fallback to the next layout")
+
+	parsed, err = ParseWithLayouts("2023-01-02T15:04:05+08:00", []string{time.RFC3339})
+	require.NoError(t, err, "Failed to parse RFC 3339")
+	assert.Equal(t, time.UTC, parsed.Location(), "Expected UTC time")
+
+	_, err = ParseWithLayouts("2023-01-02", usFirst)
+	require.Error(t, err, "Expected unmatched input to be rejected")
+	assert.Contains(t, err.Error(), "02/01/2006", "Expected error to list attempted layouts")
+	_, err = ParseWithLayouts("2023-01-02", nil)
+	assert.Error(t, err, "Expected empty layout list to be rejected")
+}
+
+func TestValidateLayout(t *testing.T) {
+	tests := []struct {
+		layout  string
+		wantErr bool
+	}{
+		{DateTimeFormat, false},
+		{time.RFC3339, false},
+		{"%Y-%m-%d %H:%M:%S", true},
+		{"YYYY-MM-DD", true},
+		{"", true},
+		{"   ", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.layout, func(t *testing.T) {
+			err := ValidateLayout(tt.layout)
+			if tt.wantErr {
+				assert.Error(t, err, "Expected layout %q to be rejected", tt.layout)
+			} else {
+				assert.NoError(t, err, "Expected layout %q to be accepted", tt.layout)
+			}
+		})
+	}
+}
+
+func TestCanonicalizeLayout(t *testing.T) {
+	canonical, err := CanonicalizeLayout("  2006-01-02   15:04:05 ")
+	require.NoError(t, err, "Failed to canonicalize layout")
+	assert.Equal(t, DateTimeFormat, canonical, "Expected whitespace to be normalized")
+
+	canonical, err = CanonicalizeLayout("2006-01-02T15:04:05Z")
+	require.NoError(t, err, "Failed to canonicalize layout")
+	assert.Equal(t, DateTimeFormatTZ, canonical, "Expected literal Z to become a zone offset")
+
+	_, err = CanonicalizeLayout("YYYY-MM-DD")
+	assert.Error(t, err, "Expected invalid layout to be rejected")
+}
+
+func TestSortableKey(t *testing.T) {
+	provider := GetProvider()
+	location, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err, "Failed to load location")
+
+	times := []time.Time{
+		time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+		time.Date(2023, 1, 1, 12, 0, 0, 5, time.UTC),
+		time.Date(2022, 12, 31, 23, 59, 59, 999999999, time.UTC),
+		time.Date(2023, 1, 1, 8, 0, 0, 1000, location),
+		time.Date(1999, 6, 15, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	keys := make([]string, len(times))
+	for i, tt := range times {
+		keys[i] = provider.SortableKey(tt)
+		assert.Len(t, keys[i], len(SortableKeyFormat), "Expected fixed width key")
+
+		parsed, err := provider.ParseSortableKey(keys[i])
+		require.NoError(t, err, "Failed to parse sortable key")
+		assert.True(t, parsed.Equal(tt), "Expected key to round-trip")
+	}
+
+	sortedTimes := append([]time.Time(nil), times...)
+	sort.Slice(sortedTimes, func(i, j int) bool { return sortedTimes[i].Before(sortedTimes[j]) })
+	sort.Strings(keys)
+	for i, key := range keys {
+		assert.Equal(t, provider.SortableKey(sortedTimes[i]), key, "Expected lexicographic order to match chronological order")
+	}
+}
+
+func TestEncodeCompact(t *testing.T) {
+	provider := GetProvider()
+	now := provider.Now()
+	encoded := EncodeCompact(now)
+	assert.Len(t, encoded, 8, "Expected fixed 8-byte encoding")
+	decoded, err := DecodeCompact(encoded)
+	require.NoError(t, err, "Failed to decode compact time")
+	assert.True(t, decoded.Equal(now), "Expected Now() to round-trip")
+	assert.Equal(t, time.UTC, decoded.Location(), "Expected decoded time to be UTC")
+
+	times := []time.Time{
+		time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+		time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC),
+		time.Date(2023, 1, 1, 12, 0, 0, 1, time.UTC),
+		time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2100, 6, 1, 0, 0, 0, 0, time.UTC),
+	}
+	encodings := make([][]byte, len(times))
+	for i, tt := range times {
+		encodings[i] = EncodeCompact(tt)
+		assert.Len(t, encodings[i], 8, "Expected fixed 8-byte encoding")
+	}
+	for i := range times {
+		for j := range times {
+			assert.Equal(t, times[i].Compare(times[j]), bytes.Compare(encodings[i], encodings[j]), "Expected byte order to match chronological order")
+		}
+	}
+
+	_, err = DecodeCompact([]byte{1, 2, 3})
+	assert.Error(t, err, "Expected wrong length to error")
+}
+
+func TestParseRange(t *testing.T) {
+	provider := GetProvider()
+	location, err := time.LoadLocation("Asia/Taipei")
+	require.NoError(t, err, "Failed to load location")
+
+	start, end, err := provider.ParseRange("2023-01-01/2023-01-31", DateFormat, location)
+	require.NoError(t, err, "Failed to parse range")
+	assert.Equal(t, time.Date(2022, 12, 31, 16, 0, 0, 0, time.UTC), start, "Expected start in UTC")
+	assert.Equal(t, time.Date(2023, 1, 30, 16, 0, 0, 0, time.UTC), end, "Expected end in UTC")
+
+	start, end, err = provider.ParseRange("01/02/2023/01/05/2023", "01/02/2006", time.UTC)
+	require.NoError(t, err, "Failed to parse range with slashes in the layout")
+	assert.Equal(t, time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC), start, "Expected start to match")
+	assert.Equal(t, time.Date(2023, 1, 5, 0, 0, 0, 0, time.UTC), end, "Expected end to match")
+
+	_, _, err = provider.ParseRange("2023-01-31/2023-01-01", DateFormat, location)
+	assert.Error(t, err, "Expected reversed range to error")
+
+	_, _, err = provider.ParseRange("2023-01-01/2023-13-01", DateFormat, location)
+	assert.Error(t, err, "Expected malformed endpoint to error")
+
+	_, _, err = provider.ParseRange("2023-01-01", DateFormat, location)
+	assert.Error(t, err, "Expected missing separator to error")
+}
@@ -0,0 +1,96 @@
+package timeManagement
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// countdownUnits 倒數顯示所使用的單位
+var countdownUnits = []struct {
+	suffix string
+	size   time.Duration
+}{
+	{"d", 24 * time.Hour},
+	{"h", time.Hour},
+	{"m", time.Minute},
+	{"s", time.Second},
+}
+
+// formatCountdown 以最大的非零單位及緊接的下一個單位顯示時長，例如 "2d 3h"；
+// 下一個單位為零時同樣顯示，例如 1 天 5 分鐘為 "1d 0h"
+func formatCountdown(d time.Duration) string {
+	parts := make([]string, 0, 2)
+	for _, u := range countdownUnits {
+		if len(parts) == 0 && d < u.size {
+			continue
+		}
+		parts = append(parts, strconv.FormatInt(int64(d/u.size), 10)+u.suffix)
+		d %= u.size
+		if len(parts) == 2 {
+			break
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func (r *realTimeProvider) CountdownString(until time.Time) string {
+	remaining := r.Until(until).Round(time.Second)
+	switch {
+	case remaining == 0:
+		return "now"
+	case remaining < 0:
+		return "past"
+	}
+	return formatCountdown(remaining)
+}
+
+// humanizeUnits 相對時間描述所使用的單位，月與年以 30 天及 365 天近似
+var humanizeUnits = []struct {
+	name string
+	size time.Duration
+}{
+	{"year", 365 * 24 * time.Hour},
+	{"month", 30 * 24 * time.Hour},
+	{"day", 24 * time.Hour},
+	{"hour", time.Hour},
+	{"minute", time.Minute},
+	{"second", time.Second},
+}
+
+// HumanizeDuration 以最大的單位粗略描述時長，例如 "5 minutes"，負數時長取絕對值
+func HumanizeDuration(d time.Duration) string {
+	if d < 0 {
+		d = -d
+	}
+	for _, u := range humanizeUnits {
+		if d >= u.size {
+			n := int64(d / u.size)
+			if n == 1 {
+				return "1 " + u.name
+			}
+			return strconv.FormatInt(n, 10) + " " + u.name + "s"
+		}
+	}
+	return "0 seconds"
+}
+
+// humanizeRelative 描述與當前時間相差 elapsed 的時間，正數為過去，例如 "5 minutes ago" 或 "in 2 hours"，
+// 相差不到一秒時為 "just now"
+func humanizeRelative(elapsed time.Duration) string {
+	switch {
+	case elapsed > -time.Second && elapsed < time.Second:
+		return "just now"
+	case elapsed > 0:
+		return HumanizeDuration(elapsed.Round(time.Second)) + " ago"
+	}
+	return "in " + HumanizeDuration(elapsed.Round(time.Second))
+}
+
+func (r *realTimeProvider) Humanize(t time.Time) string {
+	return humanizeRelative(r.Since(t))
+}
+
+func (r *realTimeProvider) FormatRelativeAbsolute(t time.Time, loc *time.Location, layout string) string {
+	return humanizeRelative(r.Since(t)) + " (" + t.In(loc).Format(layout) + ")"
+}
@@ -0,0 +1,114 @@
+package timeManagement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountdownString(t *testing.T) {
+	provider := GetProvider()
+	mockTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	provider.SetMockTime(mockTime)
+	defer provider.ClearMockTime()
+
+	tests := []struct {
+		delta    time.Duration
+		expected string
+	}{
+		{2*24*time.Hour + 3*time.Hour, "2d 3h"},
+		{2*24*time.Hour + 3*time.Hour + 15*time.Minute, "2d 3h"},
+		{90 * time.Minute, "1h 30m"},
+		{45 * time.Second, "45s"},
+		{time.Hour, "1h 0m"},
+		{24*time.Hour + 5*time.Minute, "1d 0h"},
+		{2*time.Minute + 3*time.Second, "2m 3s"},
+		{0, "now"},
+		{-time.Hour, "past"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.delta.String(), func(t *testing.T) {
+			assert.Equal(t, tt.expected, provider.CountdownString(mockTime.Add(tt.delta)), "Expected countdown string to match")
+		})
+	}
+}
+
+func TestFormatRelativeAbsolute(t *testing.T) {
+	provider := GetProvider()
+	mockTime := time.Date(2023, 1, 1, 12, 5, 0, 0, time.UTC)
+	provider.SetMockTime(mockTime)
+	defer provider.ClearMockTime()
+
+	location, err := time.LoadLocation("Asia/Taipei")
+	require.NoError(t, err, "Failed to load location")
+	layout := "2006-01-02 15:04"
+
+	tests := []struct {
+		t        time.Time
+		loc      *time.Location
+		expected string
+	}{
+		{mockTime.Add(-5 * time.Minute), time.UTC, "5 minutes ago (2023-01-01 12:00)"},
+		{mockTime.Add(5 * time.Minute), time.UTC, "in 5 minutes (2023-01-01 12:10)"},
+		{mockTime.Add(-time.Hour), location, "1 hour ago (2023-01-01 19:05)"},
+		{mockTime.Add(-3 * 24 * time.Hour), time.UTC, "3 days ago (2022-12-29 12:05)"},
+		{mockTime, time.UTC, "just now (2023-01-01 12:05)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			assert.Equal(t, tt.expected, provider.FormatRelativeAbsolute(tt.t, tt.loc, layout), "Expected combined string to match")
+		})
+	}
+}
+
+func TestHumanize(t *testing.T) {
+	provider := GetProvider()
+	mockTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	provider.SetFrozenTime(mockTime)
+	defer provider.ClearMockTime()
+
+	tests := []struct {
+		t        time.Time
+		expected string
+	}{
+		{mockTime, "just now"},
+		{mockTime.Add(-500 * time.Millisecond), "just now"},
+		{mockTime.Add(900 * time.Millisecond), "just now"},
+		{mockTime.Add(-time.Second), "1 second ago"},
+		{mockTime.Add(-3 * time.Minute), "3 minutes ago"},
+		{mockTime.Add(2 * time.Hour), "in 2 hours"},
+		{mockTime.Add(-400 * 24 * time.Hour), "1 year ago"},
+		{mockTime.Add(3 * 365 * 24 * time.Hour), "in 3 years"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			assert.Equal(t, tt.expected, provider.Humanize(tt.t), "Expected humanized string to match")
+		})
+	}
+}
+
+func TestHumanizeDuration(t *testing.T) {
+	tests := []struct {
+		d        time.Duration
+		expected string
+	}{
+		{0, "0 seconds"},
+		{500 * time.Millisecond, "0 seconds"},
+		{time.Second, "1 second"},
+		{90 * time.Second, "1 minute"},
+		{-2 * time.Hour, "2 hours"},
+		{45 * 24 * time.Hour, "1 month"},
+		{800 * 24 * time.Hour, "2 years"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.d.String(), func(t *testing.T) {
+			assert.Equal(t, tt.expected, HumanizeDuration(tt.d), "Expected humanized duration to match")
+		})
+	}
+}
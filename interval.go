@@ -0,0 +1,135 @@
+package timeManagement
+
+import (
+	"time"
+)
+
+// orderedInterval 返回依先後排序的區間端點
+func orderedInterval(start, end time.Time) (time.Time, time.Time) {
+	if end.Before(start) {
+		return end, start
+	}
+	return start, end
+}
+
+// overlapDuration 返回兩個區間重疊的時長，不重疊時返回 0
+func overlapDuration(start1, end1, start2, end2 time.Time) time.Duration {
+	start, end := start1, end1
+	if start2.After(start) {
+		start = start2
+	}
+	if end2.Before(end) {
+		end = end2
+	}
+	if !end.After(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
+
+// OverlapFraction 返回兩個區間的重疊時長佔較短區間長度的比例，範圍為 [0, 1]
+// 端點顛倒的區間會先排序；不重疊或任一區間長度為零時返回 0
+func OverlapFraction(start1, end1, start2, end2 time.Time) float64 {
+	start1, end1 = orderedInterval(start1, end1)
+	start2, end2 = orderedInterval(start2, end2)
+
+	shorter := end1.Sub(start1)
+	if length := end2.Sub(start2); length < shorter {
+		shorter = length
+	}
+	if shorter <= 0 {
+		return 0
+	}
+	return float64(overlapDuration(start1, end1, start2, end2)) / float64(shorter)
+}
+
+// TimeRange 半開區間 [Start, End)，所有比較皆以UTC進行；
+// 端點顛倒（End 早於 Start）的區間會先排序，長度為零的區間不包含任何時刻
+type TimeRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// normalized 返回端點已排序且轉換為UTC的區間
+func (tr TimeRange) normalized() TimeRange {
+	start, end := orderedInterval(tr.Start.UTC(), tr.End.UTC())
+	return TimeRange{Start: start, End: end}
+}
+
+// Contains 判斷 t 是否位於 [Start, End) 之間
+func (tr TimeRange) Contains(t time.Time) bool {
+	n := tr.normalized()
+	return !t.Before(n.Start) && t.Before(n.End)
+}
+
+// Overlaps 判斷兩個區間是否有長度大於零的重疊，僅端點相接的區間不算重疊
+func (tr TimeRange) Overlaps(other TimeRange) bool {
+	_, ok := tr.Intersection(other)
+	return ok
+}
+
+// Duration 返回區間長度，端點顛倒時返回排序後的長度
+func (tr TimeRange) Duration() time.Duration {
+	n := tr.normalized()
+	return n.End.Sub(n.Start)
+}
+
+// Intersection 返回兩個區間的交集（UTC），沒有長度大於零的重疊時返回 false
+func (tr TimeRange) Intersection(other TimeRange) (TimeRange, bool) {
+	a, b := tr.normalized(), other.normalized()
+	if overlapDuration(a.Start, a.End, b.Start, b.End) == 0 {
+		return TimeRange{}, false
+	}
+	start, end := a.Start, a.End
+	if b.Start.After(start) {
+		start = b.Start
+	}
+	if b.End.Before(end) {
+		end = b.End
+	}
+	return TimeRange{Start: start, End: end}, true
+}
+
+// ShardIndex 將時間依 shardWidth 向下取整為區塊後對 numShards 取餘數，返回確定的分片索引
+// numShards 或 shardWidth 非正數時 panic
+func ShardIndex(t time.Time, numShards int, shardWidth time.Duration) int {
+	if numShards <= 0 {
+		panic("Number of shards must be positive")
+	}
+	if shardWidth <= 0 {
+		panic("Shard width must be positive")
+	}
+	bucket := floorDiv(t.UnixNano(), int64(shardWidth))
+	index := bucket % int64(numShards)
+	if index < 0 {
+		index += int64(numShards)
+	}
+	return int(index)
+}
+
+// floorDiv 向下取整的整數除法，負數時與 Go 的截斷除法不同
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+// Linspace 返回從 start 到 end（含兩端）平均分布的 n 個時間，n 小於 2 時 panic
+// 間距以商數與餘數分開計算，確保最後一個元素精確等於 end
+func Linspace(start, end time.Time, n int) []time.Time {
+	if n < 2 {
+		panic("Linspace requires at least 2 points")
+	}
+	total := end.Sub(start)
+	steps := time.Duration(n - 1)
+	quotient, remainder := total/steps, total%steps
+
+	points := make([]time.Time, n)
+	for i := range points {
+		step := time.Duration(i)
+		points[i] = start.Add(quotient*step + remainder*step/steps).UTC()
+	}
+	return points
+}
@@ -0,0 +1,162 @@
+package timeManagement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverlapFraction(t *testing.T) {
+	base := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time {
+		return base.Add(time.Duration(minutes) * time.Minute)
+	}
+
+	tests := []struct {
+		name     string
+		start1   time.Time
+		end1     time.Time
+		start2   time.Time
+		end2     time.Time
+		expected float64
+	}{
+		{"fully contained", at(0), at(60), at(10), at(20), 1},
+		{"half overlapping", at(0), at(60), at(30), at(90), 0.5},
+		{"shorter half covered", at(0), at(60), at(50), at(70), 0.5},
+		{"disjoint", at(0), at(60), at(90), at(120), 0},
+		{"touching", at(0), at(60), at(60), at(120), 0},
+		{"zero length", at(0), at(60), at(30), at(30), 0},
+		{"inverted", at(60), at(0), at(30), at(90), 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.expected, OverlapFraction(tt.start1, tt.end1, tt.start2, tt.end2), 1e-9, "Expected overlap fraction to match")
+		})
+	}
+}
+
+func TestTimeRange(t *testing.T) {
+	base := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time {
+		return base.Add(time.Duration(minutes) * time.Minute)
+	}
+	location, err := time.LoadLocation("Asia/Taipei")
+	require.NoError(t, err, "Failed to load location")
+
+	t.Run("Contains", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			r        TimeRange
+			t        time.Time
+			expected bool
+		}{
+			{"start is included", TimeRange{at(0), at(60)}, at(0), true},
+			{"inside", TimeRange{at(0), at(60)}, at(30), true},
+			{"end is excluded", TimeRange{at(0), at(60)}, at(60), false},
+			{"before", TimeRange{at(0), at(60)}, at(-1), false},
+			{"zero length", TimeRange{at(30), at(30)}, at(30), false},
+			{"inverted", TimeRange{at(60), at(0)}, at(30), true},
+			{"other location", TimeRange{at(0).In(location), at(60).In(location)}, at(30), true},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.Equal(t, tt.expected, tt.r.Contains(tt.t), "Expected contains to match")
+			})
+		}
+	})
+
+	t.Run("Duration", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			r        TimeRange
+			expected time.Duration
+		}{
+			{"normal", TimeRange{at(0), at(60)}, time.Hour},
+			{"zero length", TimeRange{at(30), at(30)}, 0},
+			{"inverted", TimeRange{at(60), at(0)}, time.Hour},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.Equal(t, tt.expected, tt.r.Duration(), "Expected duration to match")
+			})
+		}
+	})
+
+	t.Run("Intersection", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			a        TimeRange
+			b        TimeRange
+			expected TimeRange
+			ok       bool
+		}{
+			{"partial overlap", TimeRange{at(0), at(60)}, TimeRange{at(30), at(90)}, TimeRange{at(30), at(60)}, true},
+			{"fully contained", TimeRange{at(0), at(60)}, TimeRange{at(10), at(20)}, TimeRange{at(10), at(20)}, true},
+			{"identical", TimeRange{at(0), at(60)}, TimeRange{at(0), at(60)}, TimeRange{at(0), at(60)}, true},
+			{"disjoint", TimeRange{at(0), at(60)}, TimeRange{at(90), at(120)}, TimeRange{}, false},
+			{"touching", TimeRange{at(0), at(60)}, TimeRange{at(60), at(120)}, TimeRange{}, false},
+			{"zero length inside", TimeRange{at(0), at(60)}, TimeRange{at(30), at(30)}, TimeRange{}, false},
+			{"inverted", TimeRange{at(60), at(0)}, TimeRange{at(90), at(30)}, TimeRange{at(30), at(60)}, true},
+			{"other location", TimeRange{at(0).In(location), at(60).In(location)}, TimeRange{at(30), at(90)}, TimeRange{at(30), at(60)}, true},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got, ok := tt.a.Intersection(tt.b)
+				assert.Equal(t, tt.ok, ok, "Expected intersection existence to match")
+				assert.Equal(t, tt.expected, got, "Expected intersection to match")
+				assert.Equal(t, tt.ok, tt.a.Overlaps(tt.b), "Expected overlaps to match")
+				assert.Equal(t, tt.ok, tt.b.Overlaps(tt.a), "Expected overlaps to be symmetric")
+			})
+		}
+	})
+}
+
+func TestShardIndex(t *testing.T) {
+	base := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	width := time.Hour
+
+	first := ShardIndex(base, 8, width)
+	assert.Equal(t, first, ShardIndex(base.Add(59*time.Minute), 8, width), "Expected timestamps in the same bucket to share a shard")
+	assert.NotEqual(t, first, ShardIndex(base.Add(time.Hour), 8, width), "Expected the next bucket to use a different shard")
+	assert.Equal(t, first, ShardIndex(base.Add(8*time.Hour), 8, width), "Expected shards to cycle every numShards buckets")
+
+	location, err := time.LoadLocation("Asia/Taipei")
+	require.NoError(t, err, "Failed to load location")
+	assert.Equal(t, first, ShardIndex(base.In(location), 8, width), "Expected shard to be independent of location")
+
+	// 1970 年以前的時間仍需落在 [0, numShards)
+	before := time.Date(1969, 12, 31, 23, 30, 0, 0, time.UTC)
+	assert.Equal(t, 7, ShardIndex(before, 8, width), "Expected pre-epoch bucket to floor correctly")
+
+	assert.Panics(t, func() { ShardIndex(base, 0, width) }, "Expected non-positive shard count to panic")
+	assert.Panics(t, func() { ShardIndex(base, 8, 0) }, "Expected non-positive shard width to panic")
+}
+
+func TestLinspace(t *testing.T) {
+	start := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	points := Linspace(start, end, 2)
+	assert.Equal(t, []time.Time{start, end}, points, "Expected only the endpoints")
+
+	points = Linspace(start, end, 5)
+	require.Len(t, points, 5, "Expected five points")
+	for i, point := range points {
+		assert.Equal(t, start.Add(time.Duration(i)*15*time.Minute), point, "Expected evenly spaced point %d", i)
+	}
+
+	// 1 秒無法被 3 整除，最後一點仍需精確等於 end
+	uneven := Linspace(start, start.Add(time.Second), 4)
+	assert.True(t, uneven[3].Equal(start.Add(time.Second)), "Expected last point to equal end exactly")
+	assert.Equal(t, start.Add(333333333), uneven[1], "Expected interior point to be floored")
+
+	same := Linspace(start, start, 3)
+	for _, point := range same {
+		assert.True(t, point.Equal(start), "Expected all points to equal start when start == end")
+	}
+
+	assert.Panics(t, func() { Linspace(start, end, 1) }, "Expected n < 2 to panic")
+}
@@ -0,0 +1,25 @@
+package timeManagement
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// SmoothStartDelay 以 seed 產生介於 [0, maxDelay] 的隨機延遲，用於錯開啟動時間，相同 seed 結果相同
+func SmoothStartDelay(maxDelay time.Duration, seed int64) time.Duration {
+	if maxDelay <= 0 {
+		return 0
+	}
+	rng := rand.New(rand.NewSource(seed))
+	if maxDelay == math.MaxInt64 {
+		return time.Duration(rng.Int63())
+	}
+	return time.Duration(rng.Int63n(int64(maxDelay) + 1))
+}
+
+func (r *realTimeProvider) SleepSmoothStart(maxDelay time.Duration, seed int64) time.Duration {
+	delay := SmoothStartDelay(maxDelay, seed)
+	r.Sleep(delay)
+	return delay
+}
@@ -0,0 +1,32 @@
+package timeManagement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSmoothStartDelay(t *testing.T) {
+	max := 10 * time.Second
+	for seed := int64(0); seed < 100; seed++ {
+		delay := SmoothStartDelay(max, seed)
+		assert.GreaterOrEqual(t, delay, time.Duration(0), "Expected delay >= 0")
+		assert.LessOrEqual(t, delay, max, "Expected delay <= max")
+		assert.Equal(t, delay, SmoothStartDelay(max, seed), "Expected delay to be reproducible for seed %d", seed)
+	}
+
+	assert.NotEqual(t, SmoothStartDelay(max, 1), SmoothStartDelay(max, 2), "Expected different seeds to spread delays")
+	assert.Zero(t, SmoothStartDelay(0, 1), "Expected zero delay for zero max")
+}
+
+func TestSleepSmoothStart(t *testing.T) {
+	provider := GetProvider()
+	provider.SetTimeScale(100.0)
+	defer provider.ClearTimeScale()
+
+	start := time.Now()
+	delay := provider.SleepSmoothStart(time.Second, 42)
+	assert.Equal(t, SmoothStartDelay(time.Second, 42), delay, "Expected returned delay to match the seeded delay")
+	assert.Less(t, time.Since(start), delay, "Expected sleep to honor the time scale")
+}
@@ -0,0 +1,48 @@
+package timeManagement
+
+import (
+	"sync"
+	"time"
+)
+
+// MonotonicWatcher 包裝時間提供者的除錯工具，記錄每次 Now() 的結果，
+// 若後一次 Now() 早於前一次則觸發回調，用於在測試中找出時間倒退的誤用
+type MonotonicWatcher struct {
+	TimeProvider
+
+	mu        sync.Mutex
+	last      time.Time
+	callbacks []func(prev, cur time.Time)
+}
+
+func (r *realTimeProvider) WatchMonotonic() *MonotonicWatcher {
+	return &MonotonicWatcher{TimeProvider: r}
+}
+
+// OnRegression 註冊時間倒退回調，prev 為先前記錄的最晚時間，cur 為倒退後的時間
+func (w *MonotonicWatcher) OnRegression(f func(prev, cur time.Time)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.callbacks = append(w.callbacks, f)
+}
+
+// Now 返回時間提供者的當前時間，並與先前記錄的最晚時間比對
+func (w *MonotonicWatcher) Now() time.Time {
+	cur := w.TimeProvider.Now()
+
+	w.mu.Lock()
+	prev := w.last
+	regressed := !prev.IsZero() && cur.Before(prev)
+	if !regressed {
+		w.last = cur
+	}
+	callbacks := append([]func(prev, cur time.Time){}, w.callbacks...)
+	w.mu.Unlock()
+
+	if regressed {
+		for _, callback := range callbacks {
+			callback(prev, cur)
+		}
+	}
+	return cur
+}
@@ -0,0 +1,36 @@
+package timeManagement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchMonotonic(t *testing.T) {
+	provider := GetProvider()
+	mockTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	provider.SetMockTime(mockTime)
+	defer provider.ClearMockTime()
+
+	watcher := provider.WatchMonotonic()
+	var regressions [][2]time.Time
+	watcher.OnRegression(func(prev, cur time.Time) {
+		regressions = append(regressions, [2]time.Time{prev, cur})
+	})
+
+	first := watcher.Now()
+	watcher.Now()
+	assert.Empty(t, regressions, "Expected no regression while time moves forward")
+
+	// 時間加速不允許負數，以將模擬時間往回設定模擬時間倒退
+	provider.SetMockTime(mockTime.Add(-time.Hour))
+	cur := watcher.Now()
+	require.Len(t, regressions, 1, "Expected regression callback to fire")
+	assert.False(t, regressions[0][0].Before(first), "Expected prev to be the latest recorded time")
+	assert.Equal(t, cur, regressions[0][1], "Expected cur to be the regressed time")
+
+	watcher.Now()
+	assert.Len(t, regressions, 2, "Expected time still behind the latest recorded time to keep reporting")
+}
@@ -0,0 +1,96 @@
+package timeManagement
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"time"
+)
+
+const (
+	// ntpPacketSize NTP 封包長度（不含擴充欄位）
+	ntpPacketSize = 48
+	// ntpEpochOffset 1900-01-01 至 1970-01-01 的秒數
+	ntpEpochOffset = 2208988800
+	// ntpDefaultPort NTP 預設埠號
+	ntpDefaultPort = "123"
+	// ntpClientHeader LI = 0、版本 = 4、模式 = 3（客戶端）
+	ntpClientHeader = 0x23
+	// ntpModeServer 伺服器回應的模式
+	ntpModeServer = 4
+)
+
+// SetUseNTP 改以 NTP 伺服器（"host" 或 "host:port"）作為時間來源，
+// 與伺服器時間共用偏移快取、重新同步間隔、重試及錯誤回調設定；以 SetUseServerTime(false, "") 停用
+func SetUseNTP(server string) {
+	mu.Lock()
+	defer mu.Unlock()
+	useServerTime = true
+	useNTP = true
+	serverURL = server
+	resetServerSyncLocked()
+}
+
+// toNTPTime 將時間編碼為 64 位元 NTP 時間戳（32 位元秒數、32 位元小數）
+func toNTPTime(t time.Time) uint64 {
+	seconds := uint64(t.Unix() + ntpEpochOffset)
+	fraction := uint64(t.Nanosecond()) << 32 / uint64(time.Second)
+	return seconds<<32 | fraction
+}
+
+// fromNTPTime 將 64 位元 NTP 時間戳解碼為UTC時間
+func fromNTPTime(ntp uint64) time.Time {
+	seconds := int64(ntp>>32) - ntpEpochOffset
+	nanos := (ntp & 0xffffffff) * uint64(time.Second) >> 32
+	return time.Unix(seconds, int64(nanos)).UTC()
+}
+
+// queryNTP 向 NTP 伺服器查詢一次，以標準公式返回相對本地UTC時間的偏移及往返延遲：
+// offset = ((t2 - t1) + (t3 - t4)) / 2，delay = (t4 - t1) - (t3 - t2)
+func queryNTP(server string, timeout time.Duration) (time.Duration, time.Duration, error) {
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, ntpDefaultPort)
+	}
+	conn, err := net.DialTimeout("udp", server, timeout)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return 0, 0, err
+	}
+
+	request := make([]byte, ntpPacketSize)
+	request[0] = ntpClientHeader
+	t1 := time.Now()
+	transmit := toNTPTime(t1)
+	binary.BigEndian.PutUint64(request[40:], transmit)
+	if _, err := conn.Write(request); err != nil {
+		return 0, 0, err
+	}
+
+	response := make([]byte, ntpPacketSize)
+	n, err := conn.Read(response)
+	if err != nil {
+		return 0, 0, err
+	}
+	t4 := time.Now()
+	if n < ntpPacketSize {
+		return 0, 0, fmt.Errorf("short NTP response: %d bytes", n)
+	}
+	if mode := response[0] & 0x07; mode != ntpModeServer {
+		return 0, 0, fmt.Errorf("unexpected NTP mode %d", mode)
+	}
+	if stratum := response[1]; stratum == 0 {
+		return 0, 0, fmt.Errorf("NTP kiss-of-death response %q", response[12:16])
+	}
+	if originate := binary.BigEndian.Uint64(response[24:]); originate != transmit {
+		return 0, 0, fmt.Errorf("NTP response does not match request")
+	}
+
+	t2 := fromNTPTime(binary.BigEndian.Uint64(response[32:]))
+	t3 := fromNTPTime(binary.BigEndian.Uint64(response[40:]))
+	offset := (t2.Sub(t1) + t3.Sub(t4)) / 2
+	delay := t4.Sub(t1) - t3.Sub(t2)
+	return offset, delay, nil
+}
@@ -0,0 +1,77 @@
+package timeManagement
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startNTPStub 啟動本地 UDP NTP 回應器，回應的時間為本地時間加上 offset
+func startNTPStub(t *testing.T, offset time.Duration) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err, "listening on UDP should not produce an error")
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		request := make([]byte, ntpPacketSize)
+		for {
+			n, addr, err := conn.ReadFrom(request)
+			if err != nil {
+				return
+			}
+			if n < ntpPacketSize {
+				continue
+			}
+			response := make([]byte, ntpPacketSize)
+			response[0] = 0x24 // LI = 0、版本 = 4、模式 = 4（伺服器）
+			response[1] = 1    // stratum
+			copy(response[24:32], request[40:48])
+			stamp := toNTPTime(time.Now().Add(offset))
+			binary.BigEndian.PutUint64(response[32:], stamp)
+			binary.BigEndian.PutUint64(response[40:], stamp)
+			conn.WriteTo(response, addr)
+		}
+	}()
+	return conn.LocalAddr().String()
+}
+
+func TestNTPTimeEncoding(t *testing.T) {
+	original := time.Date(2023, 1, 1, 12, 0, 0, 123456789, time.UTC)
+	assert.WithinDuration(t, original, fromNTPTime(toNTPTime(original)), time.Nanosecond, "NTP timestamp should round trip")
+	assert.Equal(t, uint64(ntpEpochOffset)<<32, toNTPTime(time.Unix(0, 0)), "Unix epoch should encode to the NTP epoch offset")
+}
+
+func TestQueryNTP(t *testing.T) {
+	offset := 3 * time.Second
+	addr := startNTPStub(t, offset)
+
+	measured, delay, err := queryNTP(addr, time.Second)
+	require.NoError(t, err, "querying the stub NTP server should not produce an error")
+	assert.InDelta(t, float64(offset), float64(measured), float64(10*time.Millisecond), "offset should match the stub server")
+	assert.GreaterOrEqual(t, delay, time.Duration(0), "delay should not be negative")
+}
+
+func TestSetUseNTP(t *testing.T) {
+	offset := -2 * time.Minute
+	addr := startNTPStub(t, offset)
+
+	SetUseNTP(addr)
+	defer SetUseServerTime(false, "")
+
+	assert.WithinDuration(t, time.Now().UTC().Add(offset), Now(), 10*time.Millisecond, "Now should reflect NTP time")
+	assert.WithinDuration(t, time.Now().UTC().Add(offset), GetProvider().Now(), 10*time.Millisecond, "provider Now should reflect NTP time")
+	assert.InDelta(t, float64(offset), float64(ClockSkew()), float64(10*time.Millisecond), "skew should match the NTP offset")
+}
+
+func TestQueryNTPTimeout(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err, "listening on UDP should not produce an error")
+	defer conn.Close()
+
+	_, _, err = queryNTP(conn.LocalAddr().String(), 50*time.Millisecond)
+	assert.Error(t, err, "querying a silent server should time out")
+}
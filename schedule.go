@@ -0,0 +1,104 @@
+package timeManagement
+
+import (
+	"time"
+)
+
+// lastFire 返回以 anchor 為起點、每 interval 觸發一次的排程在 now 或之前最近一次的觸發時間
+// 排程尚未開始觸發或 interval 非正數時返回 false
+func lastFire(anchor time.Time, interval time.Duration, now time.Time) (time.Time, bool) {
+	if interval <= 0 || now.Before(anchor) {
+		return time.Time{}, false
+	}
+	n := now.Sub(anchor) / interval
+	return anchor.Add(n * interval).UTC(), true
+}
+
+// LastCommonFire 返回所有排程在 now 或之前皆已觸發的最晚時間，即各排程最近一次觸發時間的最大值
+// 每個排程為 [anchor time.Time, interval time.Duration]，
+// 任一排程格式錯誤或尚未觸發時返回零值時間
+func LastCommonFire(schedules [][2]interface{}, now time.Time) time.Time {
+	var latest time.Time
+	for _, schedule := range schedules {
+		anchor, ok := schedule[0].(time.Time)
+		if !ok {
+			return time.Time{}
+		}
+		interval, ok := schedule[1].(time.Duration)
+		if !ok {
+			return time.Time{}
+		}
+		fired, ok := lastFire(anchor, interval, now)
+		if !ok {
+			return time.Time{}
+		}
+		if fired.After(latest) {
+			latest = fired
+		}
+	}
+	return latest
+}
+
+// RecurrencesCollide 檢查兩個週期性事件在 horizon 內是否有重疊，並返回第一次重疊的開始時間
+// 搜尋範圍自兩者較早的 anchor 起算 horizon，事件 A 於 anchorA + n*intervalA 開始並持續 durA，事件 B 同理
+func RecurrencesCollide(anchorA time.Time, intervalA time.Duration, durA time.Duration, anchorB time.Time, intervalB time.Duration, durB time.Duration, horizon time.Duration) (bool, time.Time) {
+	if intervalA <= 0 || intervalB <= 0 || durA <= 0 || durB <= 0 || horizon <= 0 {
+		return false, time.Time{}
+	}
+
+	start := anchorA
+	if anchorB.Before(start) {
+		start = anchorB
+	}
+	end := start.Add(horizon)
+
+	a, b := anchorA, anchorB
+	for a.Before(end) && b.Before(end) {
+		aEnd, bEnd := a.Add(durA), b.Add(durB)
+		switch {
+		case !aEnd.After(b):
+			a = a.Add(intervalA)
+		case !bEnd.After(a):
+			b = b.Add(intervalB)
+		default:
+			collision := a
+			if b.After(collision) {
+				collision = b
+			}
+			return true, collision.UTC()
+		}
+	}
+	return false, time.Time{}
+}
+
+func (r *realTimeProvider) NextMatching(matcher func(time.Time) bool, step time.Duration, horizon time.Duration, loc *time.Location) (time.Time, bool) {
+	if step <= 0 {
+		return time.Time{}, false
+	}
+	now := r.Now()
+	limit := now.Add(horizon)
+	// 候選時間以 loc 當地時間對齊 step，使如 "當地午夜" 的條件能以 24 小時的 step 命中；
+	// 每次重新對齊，日光節約時間切換後仍落在當地的邊界上
+	for candidate := nextBoundary(now, step, loc); !candidate.After(limit); candidate = nextBoundary(candidate, step, loc) {
+		if matcher(candidate.In(loc)) {
+			return candidate.UTC(), true
+		}
+	}
+	return time.Time{}, false
+}
+
+func (r *realTimeProvider) DutyCyclePhase(anchor time.Time, onDuration, offDuration time.Duration) (bool, time.Duration) {
+	period := onDuration + offDuration
+	if period <= 0 {
+		return false, 0
+	}
+	// anchor 之前的時間同樣依週期往回推算
+	elapsed := r.Since(anchor) % period
+	if elapsed < 0 {
+		elapsed += period
+	}
+	if elapsed < onDuration {
+		return true, onDuration - elapsed
+	}
+	return false, period - elapsed
+}
@@ -0,0 +1,141 @@
+package timeManagement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLastCommonFire(t *testing.T) {
+	anchor := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	schedules := [][2]interface{}{
+		{anchor, 10 * time.Minute},
+		{anchor.Add(5 * time.Minute), 15 * time.Minute},
+	}
+
+	// 00:37 時，第一個排程最近觸發於 00:30，第二個於 00:35
+	now := anchor.Add(37 * time.Minute)
+	assert.Equal(t, anchor.Add(35*time.Minute), LastCommonFire(schedules, now), "Expected the latest of the most recent fires")
+
+	// 00:42 時，第一個排程最近觸發於 00:40，第二個於 00:35
+	now = anchor.Add(42 * time.Minute)
+	assert.Equal(t, anchor.Add(40*time.Minute), LastCommonFire(schedules, now), "Expected the latest of the most recent fires")
+}
+
+func TestLastCommonFireNotYetFired(t *testing.T) {
+	anchor := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	schedules := [][2]interface{}{
+		{anchor, 10 * time.Minute},
+		{anchor.Add(time.Hour), 15 * time.Minute},
+	}
+	assert.True(t, LastCommonFire(schedules, anchor.Add(30*time.Minute)).IsZero(), "Expected zero time when a schedule has not fired yet")
+
+	invalid := [][2]interface{}{{anchor, "10m"}}
+	assert.True(t, LastCommonFire(invalid, anchor.Add(30*time.Minute)).IsZero(), "Expected zero time for a malformed schedule")
+}
+
+func TestRecurrencesCollide(t *testing.T) {
+	anchor := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		anchorB   time.Time
+		intervalA time.Duration
+		intervalB time.Duration
+		horizon   time.Duration
+		collide   bool
+		first     time.Time
+	}{
+		// A: 每 60 分鐘於 :00 開始 10 分鐘；B: 每 45 分鐘自 00:20 開始 10 分鐘，01:05 時兩者重疊
+		{"colliding", anchor.Add(20 * time.Minute), time.Hour, 45 * time.Minute, 24 * time.Hour, true, anchor.Add(time.Hour + 5*time.Minute)},
+		// 相同間隔但錯開 30 分鐘，永不重疊
+		{"interleaved", anchor.Add(30 * time.Minute), time.Hour, time.Hour, 24 * time.Hour, false, time.Time{}},
+		// 重疊發生在 horizon 之後
+		{"beyond horizon", anchor.Add(20 * time.Minute), time.Hour, 45 * time.Minute, time.Hour, false, time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collide, first := RecurrencesCollide(anchor, tt.intervalA, 10*time.Minute, tt.anchorB, tt.intervalB, 10*time.Minute, tt.horizon)
+			assert.Equal(t, tt.collide, collide, "Expected collision result to match")
+			assert.True(t, tt.first.Equal(first), "Expected first collision %v, got %v", tt.first, first)
+		})
+	}
+}
+
+func TestNextMatching(t *testing.T) {
+	provider := GetProvider()
+	provider.SetMockTime(time.Date(2023, 1, 1, 12, 34, 56, 0, time.UTC))
+	defer provider.ClearMockTime()
+
+	topOfHour := func(t time.Time) bool {
+		return t.Minute() == 0 && t.Second() == 0
+	}
+
+	next, ok := provider.NextMatching(topOfHour, time.Minute, 24*time.Hour, time.UTC)
+	assert.True(t, ok, "Expected a matching instant within the horizon")
+	assert.Equal(t, time.Date(2023, 1, 1, 13, 0, 0, 0, time.UTC), next, "Expected the next top of the hour")
+
+	// 加爾各答為 UTC+5:30，當地整點落在 UTC 的半點
+	location, err := time.LoadLocation("Asia/Kolkata")
+	require.NoError(t, err, "Failed to load location")
+	next, ok = provider.NextMatching(topOfHour, time.Minute, 24*time.Hour, location)
+	assert.True(t, ok, "Expected a matching instant within the horizon")
+	assert.Equal(t, time.Date(2023, 1, 1, 13, 30, 0, 0, time.UTC), next, "Expected the next local top of the hour")
+
+	_, ok = provider.NextMatching(topOfHour, time.Minute, 10*time.Minute, time.UTC)
+	assert.False(t, ok, "Expected no match within a short horizon")
+
+	// step 等於條件的週期時，候選時間須以當地時間對齊
+	next, ok = provider.NextMatching(topOfHour, time.Hour, 24*time.Hour, location)
+	assert.True(t, ok, "Expected local top of the hour to match with an hourly step")
+	assert.Equal(t, time.Date(2023, 1, 1, 13, 30, 0, 0, time.UTC), next, "Expected the next local top of the hour")
+
+	taipei, err := time.LoadLocation("Asia/Taipei")
+	require.NoError(t, err, "Failed to load location")
+	provider.SetMockTime(time.Date(2023, 1, 1, 10, 0, 0, 0, taipei))
+	midnight := func(t time.Time) bool {
+		return t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0
+	}
+	next, ok = provider.NextMatching(midnight, 24*time.Hour, 72*time.Hour, taipei)
+	assert.True(t, ok, "Expected local midnight to match with a daily step")
+	assert.Equal(t, time.Date(2023, 1, 2, 0, 0, 0, 0, taipei).UTC(), next, "Expected the next local midnight")
+
+	// 紐約 2023-03-12 凌晨 2 點切換為夏令時間，隔天的午夜須以切換後的偏移對齊
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err, "Failed to load location")
+	provider.SetFrozenTime(time.Date(2023, 3, 12, 0, 30, 0, 0, newYork))
+	next, ok = provider.NextMatching(midnight, 24*time.Hour, 72*time.Hour, newYork)
+	assert.True(t, ok, "Expected local midnight to match across the DST change")
+	assert.Equal(t, time.Date(2023, 3, 13, 0, 0, 0, 0, newYork).UTC(), next, "Expected the local midnight right after the DST change")
+}
+
+func TestDutyCyclePhase(t *testing.T) {
+	provider := GetProvider()
+	defer provider.ClearMockTime()
+	anchor := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		offset    time.Duration
+		on        bool
+		remaining time.Duration
+	}{
+		{0, true, 10 * time.Minute},
+		{4 * time.Minute, true, 6 * time.Minute},
+		{10 * time.Minute, false, 5 * time.Minute},
+		{13 * time.Minute, false, 2 * time.Minute},
+		{15 * time.Minute, true, 10 * time.Minute},
+		{-2 * time.Minute, false, 2 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.offset.String(), func(t *testing.T) {
+			provider.SetMockTime(anchor.Add(tt.offset))
+			on, remaining := provider.DutyCyclePhase(anchor, 10*time.Minute, 5*time.Minute)
+			assert.Equal(t, tt.on, on, "Expected phase to match")
+			assert.InDelta(t, float64(tt.remaining), float64(remaining), float64(10*time.Millisecond), "Expected remaining time to match")
+		})
+	}
+}
@@ -8,10 +8,31 @@ import (
 	"time"
 )
 
+const (
+	// DefaultServerTimeSyncInterval 預設重新同步伺服器時間偏移的間隔
+	DefaultServerTimeSyncInterval = 10 * time.Minute
+
+	// DefaultServerTimeTimeout 預設獲取伺服器時間的請求逾時
+	DefaultServerTimeTimeout = 5 * time.Second
+)
+
 var (
-	useServerTime bool
-	serverURL     string
-	mu            sync.RWMutex
+	useServerTime      bool
+	useNTP             bool
+	serverURL          string
+	serverOffset       time.Duration
+	serverRTT          time.Duration
+	offsetSynced       bool
+	lastSync           time.Time
+	lastAttempt        time.Time
+	serverGeneration   uint64
+	serverSyncInterval = DefaultServerTimeSyncInterval
+	serverClient       = newDefaultServerTimeClient()
+	serverEndpoint     = DefaultServerTimeEndpoint
+	serverRetries      int
+	serverBackoff      = DefaultServerTimeBackoff
+	serverErrorHandler = func(error) {}
+	mu                 sync.RWMutex
 )
 
 // TimeResponse 定義時間響應的結構
@@ -19,33 +40,297 @@ type TimeResponse struct {
 	CurrentTime string `json:"currentTime"`
 }
 
+// ServerTimeFormat 時間伺服器回應中時間欄位的格式
+type ServerTimeFormat int
+
+const (
+	// ServerTimeRFC3339 RFC 3339 字串，可包含小數秒
+	ServerTimeRFC3339 ServerTimeFormat = iota
+	// ServerTimeUnixSeconds Unix 秒數，可為數字或數字字串
+	ServerTimeUnixSeconds
+	// ServerTimeUnixMillis Unix 毫秒數，可為數字或數字字串
+	ServerTimeUnixMillis
+	// ServerTimeDateHeader 以 HEAD 請求讀取標準的 Date 回應標頭，不需專用的時間端點，精度為秒，忽略 Field
+	ServerTimeDateHeader
+)
+
+// ServerTimeEndpoint 時間伺服器的端點設定
+type ServerTimeEndpoint struct {
+	// 附加於伺服器網址後的路徑，例如 "/api/v1/now"
+	Path string
+	// JSON 回應中時間欄位的名稱
+	Field  string
+	Format ServerTimeFormat
+}
+
+// DefaultServerTimeEndpoint 預設端點，即 GET /time 返回 {"currentTime": RFC 3339 字串}
+var DefaultServerTimeEndpoint = ServerTimeEndpoint{
+	Path:   "/time",
+	Field:  "currentTime",
+	Format: ServerTimeRFC3339,
+}
+
 // SetUseServerTime 設置是否使用伺服器時間
 func SetUseServerTime(use bool, url string) {
 	mu.Lock()
 	defer mu.Unlock()
 	useServerTime = use
+	useNTP = false
 	serverURL = url
+	resetServerSyncLocked()
 }
 
-// Now 返回當前時間，根據配置選擇使用本地時間或伺服器時間
-func Now() time.Time {
+// EnableServerTime 啟用伺服器時間，套件的 Now() 及所有 TimeProvider 的 Now() 皆以本地時鐘加上與時間伺服器的偏移為基準，
+// 此設定為整個行程共用，不屬於個別的 TimeProvider
+func EnableServerTime(url string) {
+	SetUseServerTime(true, url)
+}
+
+// DisableServerTime 停用伺服器時間，恢復使用本地時鐘
+func DisableServerTime() {
+	SetUseServerTime(false, "")
+}
+
+// resetServerSyncLocked 切換時間來源時清除同步狀態，進行中的同步完成後不會寫入舊來源的偏移，呼叫者須持有 mu
+func resetServerSyncLocked() {
+	serverOffset = 0
+	serverRTT = 0
+	offsetSynced = false
+	lastAttempt = time.Time{}
+	serverGeneration++
+}
+
+// newDefaultServerTimeClient 建立帶有預設逾時的 HTTP 客戶端，避免時間伺服器無回應時 Now() 永久阻塞
+func newDefaultServerTimeClient() *http.Client {
+	return &http.Client{Timeout: DefaultServerTimeTimeout}
+}
+
+// SetServerTimeClient 設置獲取伺服器時間使用的 HTTP 客戶端，傳入 nil 時恢復預設客戶端
+func SetServerTimeClient(client *http.Client) {
+	if client == nil {
+		client = newDefaultServerTimeClient()
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	serverClient = client
+}
+
+// DefaultServerTimeBackoff 預設獲取伺服器時間失敗後重試的退避策略
+var DefaultServerTimeBackoff = BackoffPolicy{
+	Initial:    100 * time.Millisecond,
+	Max:        time.Second,
+	Multiplier: 2,
+}
+
+// SetServerTimeRetry 設置獲取伺服器時間失敗時的重試次數及指數退避策略，預設不重試
+func SetServerTimeRetry(retries int, policy BackoffPolicy) {
+	mu.Lock()
+	defer mu.Unlock()
+	serverRetries = retries
+	serverBackoff = policy
+}
+
+// SetServerTimeErrorHandler 設置同步伺服器時間失敗（含重試後）時的錯誤回調，可轉交給日誌或監控，
+// 失敗時 Now() 仍改用本地UTC時間；預設不做任何處理，傳入 nil 時恢復預設
+func SetServerTimeErrorHandler(handler func(error)) {
+	if handler == nil {
+		handler = func(error) {}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	serverErrorHandler = handler
+}
+
+// SetServerTimeEndpoint 設置時間伺服器的路徑、時間欄位名稱及格式，以對接既有的時間服務
+func SetServerTimeEndpoint(endpoint ServerTimeEndpoint) {
+	mu.Lock()
+	defer mu.Unlock()
+	serverEndpoint = endpoint
+}
+
+// SetServerTimeSyncInterval 設置重新同步伺服器時間偏移的間隔，以修正本地時鐘漂移，
+// 同步失敗後同樣等待此間隔才再次嘗試；小於等於 0 時只在首次使用時同步，從未成功時以 DefaultServerTimeSyncInterval 重試
+func SetServerTimeSyncInterval(interval time.Duration) {
+	mu.Lock()
+	defer mu.Unlock()
+	serverSyncInterval = interval
+}
+
+// SyncServerOffset 從時間伺服器獲取一次時間並計算與本地UTC時間的偏移，
+// 之後 Now() 直接返回本地UTC時間加上偏移，直到下次重新同步前不再發出請求
+func SyncServerOffset() (time.Duration, error) {
 	mu.RLock()
-	defer mu.RUnlock()
+	url, client, endpoint, ntp := serverURL, serverClient, serverEndpoint, useNTP
+	retries, backoff, generation := serverRetries, serverBackoff, serverGeneration
+	mu.RUnlock()
 
-	if useServerTime {
-		serverTime, err := getServerTime()
+	var offset, rtt time.Duration
+	var err error
+	for attempt := 0; ; attempt++ {
+		if ntp {
+			offset, rtt, err = queryNTP(url, DefaultServerTimeTimeout)
+		} else {
+			offset, rtt, err = measureServerOffset(client, url, endpoint)
+		}
 		if err == nil {
-			return serverTime
+			break
+		}
+		if attempt >= retries {
+			return 0, err
 		}
-		fmt.Println("Error getting server time, falling back to local UTC time:", err)
+		time.Sleep(backoff.Backoff(attempt))
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if generation != serverGeneration {
+		// 同步期間時間來源已被切換，結果屬於舊的來源
+		return offset, nil
+	}
+	serverOffset = offset
+	serverRTT = rtt
+	offsetSynced = true
+	lastSync = time.Now()
+	lastAttempt = lastSync
+	return offset, nil
+}
+
+// measureServerOffset 從時間伺服器獲取一次時間，返回相對本地UTC時間的偏移及請求往返時間
+func measureServerOffset(client *http.Client, url string, endpoint ServerTimeEndpoint) (time.Duration, time.Duration, error) {
+	start := time.Now()
+	serverTime, err := fetchServerTime(client, url, endpoint)
+	if err != nil {
+		return 0, 0, err
+	}
+	end := time.Now()
+
+	// 與 NTP 相同，假設伺服器在請求往返的中點產生時間戳，以 RTT/2 修正讀到時已過時的伺服器時間
+	rtt := end.Sub(start)
+	return serverTime.Add(rtt / 2).Sub(end.UTC()), rtt, nil
+}
+
+// ClockSkew 返回最近一次同步計算出的伺服器時間與本地UTC時間的差值，正數表示本地時鐘落後，
+// 可用於診斷虛擬機時鐘漂移；未啟用伺服器時間或尚未同步時返回 0
+func ClockSkew() time.Duration {
+	mu.RLock()
+	defer mu.RUnlock()
+	if !useServerTime || !offsetSynced {
+		return 0
 	}
+	return serverOffset
+}
+
+// ServerTimeInfo 最近一次伺服器時間同步的結果
+type ServerTimeInfo struct {
+	// 是否已成功同步
+	Synced bool
+	// 最近一次同步的請求往返時間
+	RTT time.Duration
+	// 估計的伺服器時間相對本地UTC時間的偏移，已以 RTT/2 修正
+	Offset time.Duration
+	// 最近一次同步完成的時間（UTC）
+	LastSync time.Time
+}
+
+// GetServerTimeInfo 返回最近一次伺服器時間同步的往返時間及估計偏移，尚未同步時 Synced 為 false
+func GetServerTimeInfo() ServerTimeInfo {
+	mu.RLock()
+	defer mu.RUnlock()
+	if !offsetSynced {
+		return ServerTimeInfo{}
+	}
+	return ServerTimeInfo{
+		Synced:   true,
+		RTT:      serverRTT,
+		Offset:   serverOffset,
+		LastSync: lastSync.UTC(),
+	}
+}
+
+// ReSync 重新從時間伺服器計算偏移
+func ReSync() (time.Duration, error) {
+	return SyncServerOffset()
+}
 
+// Now 返回當前時間，根據配置選擇使用本地時間或伺服器時間
+func Now() time.Time {
+	if offset, ok := serverTimeOffset(); ok {
+		return time.Now().UTC().Add(offset)
+	}
 	return time.Now().UTC()
 }
 
-// getServerTime 從時間伺服器獲取當前時間
-func getServerTime() (time.Time, error) {
-	resp, err := http.Get(serverURL + "/time")
+// serverSyncDueLocked 判斷距離上次嘗試同步（無論成功與否）是否已超過同步間隔，呼叫者須持有 mu
+func serverSyncDueLocked(now time.Time) bool {
+	interval := serverSyncInterval
+	if interval <= 0 {
+		if offsetSynced {
+			return false
+		}
+		interval = DefaultServerTimeSyncInterval
+	}
+	return lastAttempt.IsZero() || now.Sub(lastAttempt) >= interval
+}
+
+// claimServerSync 返回快取的偏移，並在需要同步時記錄本次嘗試的時間後返回 claimed 為 true，
+// 同一個同步間隔內只有一個呼叫會取得同步的權利，其他呼叫直接使用快取
+func claimServerSync() (offset time.Duration, enabled, synced, claimed bool) {
+	mu.RLock()
+	due := useServerTime && serverSyncDueLocked(time.Now())
+	offset, enabled, synced = serverOffset, useServerTime, offsetSynced
+	mu.RUnlock()
+	if !due {
+		return offset, enabled, synced, false
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	now := time.Now()
+	if !useServerTime || !serverSyncDueLocked(now) {
+		return serverOffset, useServerTime, offsetSynced, false
+	}
+	lastAttempt = now
+	return serverOffset, useServerTime, offsetSynced, true
+}
+
+// syncServerOffsetOrReport 同步偏移，失敗時交給錯誤回調
+func syncServerOffsetOrReport() (time.Duration, error) {
+	offset, err := SyncServerOffset()
+	if err != nil {
+		mu.RLock()
+		handler := serverErrorHandler
+		mu.RUnlock()
+		handler(err)
+	}
+	return offset, err
+}
+
+// serverTimeOffset 返回伺服器時間相對本地UTC時間的偏移，未啟用伺服器時間或從未成功同步時返回 false。
+// 首次同步在呼叫中進行；之後超過同步間隔時於背景重新同步並繼續返回快取的偏移，
+// 同步失敗後在下一個同步間隔前不再嘗試，避免時間伺服器異常時每次 Now() 都發出請求
+func serverTimeOffset() (time.Duration, bool) {
+	offset, enabled, synced, claimed := claimServerSync()
+	if !claimed {
+		return offset, enabled && synced
+	}
+	if synced {
+		go syncServerOffsetOrReport()
+		return offset, true
+	}
+	newOffset, err := syncServerOffsetOrReport()
+	if err != nil {
+		return 0, false
+	}
+	return newOffset, true
+}
+
+// fetchServerTime 以 client 從指定的時間伺服器端點獲取當前時間
+func fetchServerTime(client *http.Client, url string, endpoint ServerTimeEndpoint) (time.Time, error) {
+	if endpoint.Format == ServerTimeDateHeader {
+		return fetchServerDateHeader(client, url+endpoint.Path)
+	}
+
+	resp, err := client.Get(url + endpoint.Path)
 	if err != nil {
 		return time.Time{}, err
 	}
@@ -55,15 +340,61 @@ func getServerTime() (time.Time, error) {
 		return time.Time{}, fmt.Errorf("failed to get time: %s", resp.Status)
 	}
 
-	var timeResponse TimeResponse
-	if err := json.NewDecoder(resp.Body).Decode(&timeResponse); err != nil {
+	var body map[string]json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
 		return time.Time{}, err
 	}
+	value, ok := body[endpoint.Field]
+	if !ok {
+		return time.Time{}, fmt.Errorf("time field %q not found in response", endpoint.Field)
+	}
+	return parseServerTimeValue(value, endpoint.Format)
+}
 
-	serverTime, err := time.Parse(time.RFC3339Nano, timeResponse.CurrentTime)
+// fetchServerDateHeader 以 HEAD 請求獲取 Date 回應標頭表示的伺服器時間
+func fetchServerDateHeader(client *http.Client, url string) (time.Time, error) {
+	resp, err := client.Head(url)
 	if err != nil {
 		return time.Time{}, err
 	}
+	resp.Body.Close()
+
+	date := resp.Header.Get("Date")
+	if date == "" {
+		return time.Time{}, fmt.Errorf("no Date header in response")
+	}
+	serverTime, err := http.ParseTime(date)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid Date header %q: %w", date, err)
+	}
+	return serverTime.UTC(), nil
+}
+
+// parseServerTimeValue 依格式解析時間欄位的 JSON 值
+func parseServerTimeValue(value json.RawMessage, format ServerTimeFormat) (time.Time, error) {
+	if format == ServerTimeRFC3339 {
+		var text string
+		if err := json.Unmarshal(value, &text); err != nil {
+			return time.Time{}, err
+		}
+		return time.Parse(time.RFC3339Nano, text)
+	}
 
-	return serverTime, nil
+	// Unix 時間戳可能以數字或字串表示
+	var number json.Number
+	if err := json.Unmarshal(value, &number); err != nil {
+		return time.Time{}, err
+	}
+	timestamp, err := number.Int64()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid unix timestamp %q: %w", number, err)
+	}
+	switch format {
+	case ServerTimeUnixSeconds:
+		return time.Unix(timestamp, 0).UTC(), nil
+	case ServerTimeUnixMillis:
+		return time.UnixMilli(timestamp).UTC(), nil
+	default:
+		return time.Time{}, fmt.Errorf("unknown server time format %d", format)
+	}
 }
@@ -3,6 +3,8 @@ package timeManagement
 import (
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -56,9 +58,355 @@ func TestServerNow(t *testing.T) {
 		if tt.useServerTime && !tt.expectError {
 			expectedTime, err := time.Parse(time.RFC3339Nano, mockTime)
 			require.NoError(t, err, "parsing mockTime should not produce an error")
-			assert.Equal(t, expectedTime, currentTime, "expected server time should match current time")
+			assert.WithinDuration(t, expectedTime, currentTime, 100*time.Millisecond, "expected server time should match current time")
 		} else if !tt.useServerTime {
 			assert.WithinDuration(t, time.Now().UTC(), currentTime, time.Millisecond, "expected local UTC time should be within 1ms of current time")
 		}
 	}
 }
+
+func TestSyncServerOffset(t *testing.T) {
+	offset := 2 * time.Hour
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"currentTime":"` + time.Now().UTC().Add(offset).Format(time.RFC3339Nano) + `"}`))
+	}))
+	defer server.Close()
+
+	SetUseServerTime(true, server.URL)
+	defer SetUseServerTime(false, "")
+
+	synced, err := SyncServerOffset()
+	require.NoError(t, err, "syncing server offset should not produce an error")
+	assert.InDelta(t, float64(offset), float64(synced), float64(100*time.Millisecond), "synced offset should match server offset")
+
+	for i := 0; i < 10; i++ {
+		assert.WithinDuration(t, time.Now().UTC().Add(offset), Now(), 100*time.Millisecond, "Now should reflect the synced offset")
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&hits), "Now should not fetch server time after syncing")
+
+	_, err = ReSync()
+	require.NoError(t, err, "re-syncing server offset should not produce an error")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&hits), "ReSync should fetch server time again")
+}
+
+func TestServerTimeOffsetCache(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"currentTime":"` + time.Now().UTC().Add(time.Hour).Format(time.RFC3339Nano) + `"}`))
+	}))
+	defer server.Close()
+
+	SetServerTimeSyncInterval(time.Hour)
+	defer SetServerTimeSyncInterval(DefaultServerTimeSyncInterval)
+	SetUseServerTime(true, server.URL)
+	defer SetUseServerTime(false, "")
+
+	for i := 0; i < 1000; i++ {
+		Now()
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&hits), "Now should fetch server time only once within the sync interval")
+	assert.WithinDuration(t, time.Now().UTC().Add(time.Hour), Now(), 100*time.Millisecond, "Now should apply the cached offset")
+}
+
+func TestServerTimeResyncInterval(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"currentTime":"` + time.Now().UTC().Format(time.RFC3339Nano) + `"}`))
+	}))
+	defer server.Close()
+
+	SetServerTimeSyncInterval(20 * time.Millisecond)
+	defer SetServerTimeSyncInterval(DefaultServerTimeSyncInterval)
+	SetUseServerTime(true, server.URL)
+	defer SetUseServerTime(false, "")
+
+	Now()
+	Now()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&hits), "Now should use the cached offset before the interval elapses")
+
+	// 超過間隔後於背景重新同步，Now() 不等待請求完成
+	time.Sleep(30 * time.Millisecond)
+	Now()
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&hits) == 2 }, time.Second, time.Millisecond, "Now should re-sync after the interval elapses")
+}
+
+func TestServerTimeBackgroundResync(t *testing.T) {
+	offset := time.Hour
+	release := make(chan struct{})
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&hits, 1) > 1 {
+			<-release
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"currentTime":"` + time.Now().UTC().Add(offset).Format(time.RFC3339Nano) + `"}`))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	SetServerTimeSyncInterval(20 * time.Millisecond)
+	defer SetServerTimeSyncInterval(DefaultServerTimeSyncInterval)
+	SetUseServerTime(true, server.URL)
+	defer SetUseServerTime(false, "")
+
+	Now()
+	time.Sleep(30 * time.Millisecond)
+	// 背景同步被阻塞時 Now() 仍立即返回快取的偏移
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		assert.WithinDuration(t, time.Now().UTC().Add(offset), Now(), 100*time.Millisecond, "Now should keep serving the cached offset while re-syncing")
+	}
+	assert.Less(t, time.Since(start), 100*time.Millisecond, "Now should not wait for the background re-sync")
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&hits) == 2 }, time.Second, time.Millisecond, "only one background re-sync should be started")
+}
+
+func TestServerTimeFailedSyncThrottle(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	var errs int32
+	SetServerTimeErrorHandler(func(error) { atomic.AddInt32(&errs, 1) })
+	defer SetServerTimeErrorHandler(nil)
+	SetServerTimeSyncInterval(time.Hour)
+	defer SetServerTimeSyncInterval(DefaultServerTimeSyncInterval)
+	SetUseServerTime(true, server.URL)
+	defer SetUseServerTime(false, "")
+
+	for i := 0; i < 100; i++ {
+		assert.WithinDuration(t, time.Now().UTC(), Now(), 10*time.Millisecond, "Now should fall back to local UTC time")
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&hits), "a failed sync should not be retried before the interval elapses")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&errs), "error handler should be called once per failed sync")
+}
+
+func TestProviderServerTime(t *testing.T) {
+	offset := time.Hour
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"currentTime":"` + time.Now().UTC().Add(offset).Format(time.RFC3339Nano) + `"}`))
+	}))
+	defer server.Close()
+
+	provider := NewProvider()
+	EnableServerTime(server.URL)
+	defer DisableServerTime()
+
+	assert.WithinDuration(t, time.Now().UTC().Add(offset), provider.Now(), 100*time.Millisecond, "provider Now should reflect server time")
+	assert.WithinDuration(t, time.Now().UTC().Add(offset), GetProvider().Now(), 100*time.Millisecond, "server time should apply to every provider")
+
+	provider.SetTimeScale(2.0)
+	assert.WithinDuration(t, time.Now().UTC().Add(offset), provider.Now(), 100*time.Millisecond, "time scale should start from server time")
+	provider.ClearTimeScale()
+
+	DisableServerTime()
+	assert.WithinDuration(t, time.Now().UTC(), provider.Now(), 10*time.Millisecond, "provider Now should use local time after disabling")
+}
+
+func TestServerTimeClientTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	SetServerTimeClient(&http.Client{Timeout: 50 * time.Millisecond})
+	defer SetServerTimeClient(nil)
+	SetUseServerTime(true, server.URL)
+	defer SetUseServerTime(false, "")
+
+	start := time.Now()
+	currentTime := Now()
+	assert.Less(t, time.Since(start), 500*time.Millisecond, "Now should fall back quickly when the server hangs")
+	assert.WithinDuration(t, time.Now().UTC(), currentTime, 10*time.Millisecond, "Now should fall back to local UTC time")
+}
+
+func TestSetServerTimeClientDefault(t *testing.T) {
+	SetServerTimeClient(nil)
+	mu.RLock()
+	defer mu.RUnlock()
+	require.NotNil(t, serverClient, "default client should be restored")
+	assert.Equal(t, DefaultServerTimeTimeout, serverClient.Timeout, "default client should have a timeout")
+}
+
+func TestServerTimeEndpoint(t *testing.T) {
+	serverTime := time.Date(2023, 1, 1, 12, 0, 0, 123000000, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/now":
+			w.Write([]byte(`{"epochMillis":` + strconv.FormatInt(serverTime.UnixMilli(), 10) + `}`))
+		case "/api/v1/unix":
+			w.Write([]byte(`{"ts":"` + strconv.FormatInt(serverTime.Unix(), 10) + `"}`))
+		case "/api/v1/rfc3339":
+			w.Write([]byte(`{"now":"` + serverTime.Format(time.RFC3339Nano) + `"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		endpoint ServerTimeEndpoint
+		expected time.Time
+	}{
+		{"Epoch millis", ServerTimeEndpoint{"/api/v1/now", "epochMillis", ServerTimeUnixMillis}, serverTime},
+		{"Unix seconds string", ServerTimeEndpoint{"/api/v1/unix", "ts", ServerTimeUnixSeconds}, serverTime.Truncate(time.Second)},
+		{"Custom field name", ServerTimeEndpoint{"/api/v1/rfc3339", "now", ServerTimeRFC3339}, serverTime},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := fetchServerTime(http.DefaultClient, server.URL, tt.endpoint)
+			require.NoError(t, err, "fetching server time should not produce an error")
+			assert.True(t, tt.expected.Equal(parsed), "expected %v, got %v", tt.expected, parsed)
+		})
+	}
+
+	_, err := fetchServerTime(http.DefaultClient, server.URL, ServerTimeEndpoint{"/api/v1/now", "missing", ServerTimeUnixMillis})
+	assert.Error(t, err, "missing field should produce an error")
+
+	SetServerTimeEndpoint(ServerTimeEndpoint{"/api/v1/now", "epochMillis", ServerTimeUnixMillis})
+	defer SetServerTimeEndpoint(DefaultServerTimeEndpoint)
+	SetUseServerTime(true, server.URL)
+	defer SetUseServerTime(false, "")
+	assert.WithinDuration(t, serverTime, Now(), 100*time.Millisecond, "Now should use the configured endpoint")
+}
+
+func TestServerTimeDateHeader(t *testing.T) {
+	serverTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/no-date" {
+			// 關閉標準函式庫自動加入的 Date 標頭
+			w.Header()["Date"] = nil
+			return
+		}
+		w.Header().Set("Date", serverTime.Format(http.TimeFormat))
+	}))
+	defer server.Close()
+
+	parsed, err := fetchServerTime(http.DefaultClient, server.URL, ServerTimeEndpoint{Path: "/", Format: ServerTimeDateHeader})
+	require.NoError(t, err, "fetching the Date header should not produce an error")
+	assert.Equal(t, serverTime, parsed, "expected server time from the Date header")
+
+	_, err = fetchServerTime(http.DefaultClient, server.URL, ServerTimeEndpoint{Path: "/no-date", Format: ServerTimeDateHeader})
+	assert.Error(t, err, "missing Date header should produce an error")
+
+	SetServerTimeEndpoint(ServerTimeEndpoint{Path: "/no-date", Format: ServerTimeDateHeader})
+	defer SetServerTimeEndpoint(DefaultServerTimeEndpoint)
+	SetUseServerTime(true, server.URL)
+	defer SetUseServerTime(false, "")
+	assert.WithinDuration(t, time.Now().UTC(), Now(), 10*time.Millisecond, "Now should fall back to local UTC time without a Date header")
+}
+
+func TestServerTimeRTTCorrection(t *testing.T) {
+	offset := time.Hour
+	delay := 50 * time.Millisecond
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// 時間戳產生於往返的中點，修正後的偏移應接近真實偏移
+		time.Sleep(delay)
+		stamp := time.Now().UTC().Add(offset)
+		time.Sleep(delay)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"currentTime":"` + stamp.Format(time.RFC3339Nano) + `"}`))
+	}))
+	defer server.Close()
+
+	SetUseServerTime(true, server.URL)
+	defer SetUseServerTime(false, "")
+	assert.False(t, GetServerTimeInfo().Synced, "info should report not synced before the first sync")
+
+	synced, err := SyncServerOffset()
+	require.NoError(t, err, "syncing server offset should not produce an error")
+
+	info := GetServerTimeInfo()
+	assert.True(t, info.Synced, "info should report synced")
+	assert.GreaterOrEqual(t, info.RTT, 2*delay, "RTT should include the handler delay")
+	assert.Equal(t, synced, info.Offset, "info offset should match the synced offset")
+	assert.InDelta(t, float64(offset), float64(info.Offset), float64(20*time.Millisecond), "offset should be corrected by RTT/2")
+	assert.WithinDuration(t, time.Now().UTC(), info.LastSync, 100*time.Millisecond, "last sync should be recent")
+}
+
+func TestServerTimeRetry(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&hits, 1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"currentTime":"` + time.Now().UTC().Add(time.Hour).Format(time.RFC3339Nano) + `"}`))
+	}))
+	defer server.Close()
+
+	var errs []error
+	SetServerTimeErrorHandler(func(err error) { errs = append(errs, err) })
+	defer SetServerTimeErrorHandler(nil)
+	SetServerTimeRetry(2, BackoffPolicy{Initial: time.Millisecond, Multiplier: 2})
+	defer SetServerTimeRetry(0, DefaultServerTimeBackoff)
+	SetUseServerTime(true, server.URL)
+	defer SetUseServerTime(false, "")
+
+	assert.WithinDuration(t, time.Now().UTC().Add(time.Hour), Now(), 100*time.Millisecond, "Now should use server time after retries succeed")
+	assert.Equal(t, int32(3), atomic.LoadInt32(&hits), "server time should be fetched until it succeeds")
+	assert.Empty(t, errs, "error handler should not be called when a retry succeeds")
+
+	atomic.StoreInt32(&hits, -10)
+	SetUseServerTime(true, server.URL)
+	assert.WithinDuration(t, time.Now().UTC(), Now(), 10*time.Millisecond, "Now should fall back to local time after exhausting retries")
+	assert.Equal(t, int32(-7), atomic.LoadInt32(&hits), "server time should be fetched once plus the configured retries")
+	assert.Len(t, errs, 1, "error handler should be called once after exhausting retries")
+}
+
+func TestSetServerTimeErrorHandler(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	var handled error
+	SetServerTimeErrorHandler(func(err error) { handled = err })
+	defer SetServerTimeErrorHandler(nil)
+	SetUseServerTime(true, server.URL)
+	defer SetUseServerTime(false, "")
+
+	assert.WithinDuration(t, time.Now().UTC(), Now(), 10*time.Millisecond, "Now should fall back to local UTC time")
+	require.Error(t, handled, "error handler should be called when the fetch fails")
+	assert.Contains(t, handled.Error(), "500", "error handler should receive the underlying error")
+}
+
+func TestClockSkew(t *testing.T) {
+	offset := -90 * time.Second
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"currentTime":"` + time.Now().UTC().Add(offset).Format(time.RFC3339Nano) + `"}`))
+	}))
+	defer server.Close()
+
+	SetUseServerTime(false, "")
+	assert.Zero(t, ClockSkew(), "skew should be zero when server time is disabled")
+
+	SetUseServerTime(true, server.URL)
+	defer SetUseServerTime(false, "")
+	assert.Zero(t, ClockSkew(), "skew should be zero before syncing")
+
+	Now()
+	assert.InDelta(t, float64(offset), float64(ClockSkew()), float64(50*time.Millisecond), "skew should match the server offset")
+}
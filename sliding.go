@@ -0,0 +1,43 @@
+package timeManagement
+
+import (
+	"sync"
+	"time"
+)
+
+// SlidingDeadline 每次活動時往後延長的截止時間，例如閒置逾時的工作階段
+type SlidingDeadline struct {
+	provider TimeProvider
+	ttl      time.Duration
+
+	mu       sync.Mutex
+	deadline time.Time
+}
+
+func (r *realTimeProvider) NewSlidingDeadline(ttl time.Duration) *SlidingDeadline {
+	return &SlidingDeadline{
+		provider: r,
+		ttl:      ttl,
+		deadline: r.Now().Add(ttl),
+	}
+}
+
+// Touch 記錄一次活動，將截止時間重設為當前時間加上 ttl
+func (s *SlidingDeadline) Touch() {
+	deadline := s.provider.Now().Add(s.ttl)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deadline = deadline
+}
+
+// Deadline 返回目前的截止時間
+func (s *SlidingDeadline) Deadline() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.deadline
+}
+
+// Expired 判斷當前時間是否已到達截止時間
+func (s *SlidingDeadline) Expired() bool {
+	return !s.provider.Now().Before(s.Deadline())
+}
@@ -0,0 +1,30 @@
+package timeManagement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlidingDeadline(t *testing.T) {
+	provider := GetProvider()
+	mockTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	provider.SetMockTime(mockTime)
+	defer provider.ClearMockTime()
+
+	deadline := provider.NewSlidingDeadline(30 * time.Minute)
+	assert.WithinDuration(t, mockTime.Add(30*time.Minute), deadline.Deadline(), 10*time.Millisecond, "Expected initial deadline to be Now()+ttl")
+
+	provider.SetMockTime(mockTime.Add(20 * time.Minute))
+	assert.False(t, deadline.Expired(), "Expected deadline not to expire partway through ttl")
+
+	deadline.Touch()
+	assert.WithinDuration(t, mockTime.Add(50*time.Minute), deadline.Deadline(), 10*time.Millisecond, "Expected Touch to extend the deadline")
+
+	provider.SetMockTime(mockTime.Add(40 * time.Minute))
+	assert.False(t, deadline.Expired(), "Expected touched deadline not to expire at the original deadline")
+
+	provider.SetMockTime(mockTime.Add(51 * time.Minute))
+	assert.True(t, deadline.Expired(), "Expected deadline to expire after ttl without activity")
+}
@@ -0,0 +1,32 @@
+package timeManagement
+
+import (
+	"fmt"
+	"time"
+)
+
+// NowSnapshot 同一時刻的各種常用表示方式
+type NowSnapshot struct {
+	UTC       time.Time
+	Local     time.Time
+	UnixSec   int64
+	UnixMilli int64
+	// UTC時間的 RFC 3339 字串，含奈秒
+	RFC3339 string
+	// 當地時間的 ISO 8601 週，如 "2023-W01"
+	ISOWeek string
+}
+
+func (r *realTimeProvider) NowAll(loc *time.Location) NowSnapshot {
+	now := r.Now().UTC()
+	local := now.In(loc)
+	year, week := local.ISOWeek()
+	return NowSnapshot{
+		UTC:       now,
+		Local:     local,
+		UnixSec:   now.Unix(),
+		UnixMilli: now.UnixMilli(),
+		RFC3339:   now.Format(time.RFC3339Nano),
+		ISOWeek:   fmt.Sprintf("%04d-W%02d", year, week),
+	}
+}
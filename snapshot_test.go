@@ -0,0 +1,30 @@
+package timeManagement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNowAll(t *testing.T) {
+	provider := GetProvider()
+	location, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err, "Failed to load location")
+	// 紐約時間為 2023-01-01 週日 19:00，仍屬 2022 年第 52 週
+	provider.SetMockTime(time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC))
+	defer provider.ClearMockTime()
+
+	snapshot := provider.NowAll(location)
+	assert.Equal(t, time.UTC, snapshot.UTC.Location(), "Expected UTC field to be UTC")
+	assert.Equal(t, location, snapshot.Local.Location(), "Expected Local field to be in the given location")
+	assert.True(t, snapshot.Local.Equal(snapshot.UTC), "Expected Local and UTC to be the same instant")
+	assert.Equal(t, snapshot.UTC.Unix(), snapshot.UnixSec, "Expected Unix seconds to describe the same instant")
+	assert.Equal(t, snapshot.UTC.UnixMilli(), snapshot.UnixMilli, "Expected Unix millis to describe the same instant")
+
+	parsed, err := time.Parse(time.RFC3339Nano, snapshot.RFC3339)
+	require.NoError(t, err, "Failed to parse RFC3339 field")
+	assert.True(t, parsed.Equal(snapshot.UTC), "Expected RFC3339 to describe the same instant")
+	assert.Equal(t, "2022-W52", snapshot.ISOWeek, "Expected local ISO week")
+}
@@ -0,0 +1,83 @@
+package timeManagement
+
+import (
+	"sync"
+	"time"
+)
+
+// StateTimer 記錄狀態轉換時間，統計一段時間內各狀態持續的時長。
+// 預設保留所有轉換紀錄，以 SetRetention 限制保留的時間範圍
+type StateTimer struct {
+	provider    TimeProvider
+	mu          sync.Mutex
+	transitions []stateTransition
+	// 保留紀錄的時間範圍，0 表示保留所有紀錄
+	retention time.Duration
+}
+
+type stateTransition struct {
+	state string
+	at    time.Time
+}
+
+func (r *realTimeProvider) NewStateTimer() *StateTimer {
+	return &StateTimer{provider: r}
+}
+
+// Transition 記錄於當前時間進入 state
+func (s *StateTimer) Transition(state string) {
+	now := s.provider.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.transitions = append(s.transitions, stateTransition{state: state, at: now})
+	s.pruneLocked(now)
+}
+
+// SetRetention 只保留最近 retention 時間內所需的轉換紀錄，之後以更大的視窗查詢時只統計最近 retention 的時長；
+// retention 為 0 時保留所有紀錄
+func (s *StateTimer) SetRetention(retention time.Duration) {
+	if retention < 0 {
+		panic("State timer retention must not be negative")
+	}
+	now := s.provider.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.retention = retention
+	s.pruneLocked(now)
+}
+
+// pruneLocked 捨棄在保留範圍開始前已結束的轉換，保留範圍開始時所處的狀態，呼叫者須持有 mu
+func (s *StateTimer) pruneLocked(now time.Time) {
+	if s.retention <= 0 {
+		return
+	}
+	windowStart := now.Add(-s.retention)
+	drop := 0
+	for drop+1 < len(s.transitions) && !s.transitions[drop+1].at.After(windowStart) {
+		drop++
+	}
+	if drop > 0 {
+		s.transitions = append(s.transitions[:0], s.transitions[drop:]...)
+	}
+}
+
+// DurationByState 返回最近 window 時間內各狀態持續的時長，最後一個狀態持續至當前時間；
+// 已設定保留範圍時，超出保留範圍的部分不計入
+func (s *StateTimer) DurationByState(window time.Duration) map[string]time.Duration {
+	now := s.provider.Now()
+	windowStart := now.Add(-window)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	durations := make(map[string]time.Duration)
+	for i, transition := range s.transitions {
+		end := now
+		if i+1 < len(s.transitions) {
+			end = s.transitions[i+1].at
+		}
+		if d := overlapDuration(transition.at, end, windowStart, now); d > 0 {
+			durations[transition.state] += d
+		}
+	}
+	return durations
+}
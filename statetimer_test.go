@@ -0,0 +1,72 @@
+package timeManagement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateTimer(t *testing.T) {
+	provider := GetProvider()
+	defer provider.ClearMockTime()
+	start := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	timer := provider.NewStateTimer()
+	provider.SetMockTime(start)
+	timer.Transition("idle")
+	provider.SetMockTime(start.Add(10 * time.Minute))
+	timer.Transition("busy")
+	provider.SetMockTime(start.Add(40 * time.Minute))
+	timer.Transition("idle")
+	provider.SetMockTime(start.Add(50 * time.Minute))
+	timer.Transition("error")
+	provider.SetMockTime(start.Add(60 * time.Minute))
+
+	tolerance := float64(10 * time.Millisecond)
+	durations := timer.DurationByState(time.Hour)
+	assert.InDelta(t, float64(20*time.Minute), float64(durations["idle"]), tolerance, "Expected idle duration over the last hour")
+	assert.InDelta(t, float64(30*time.Minute), float64(durations["busy"]), tolerance, "Expected busy duration over the last hour")
+	assert.InDelta(t, float64(10*time.Minute), float64(durations["error"]), tolerance, "Expected error duration over the last hour")
+
+	// 最近 25 分鐘只涵蓋 busy 的最後 5 分鐘
+	durations = timer.DurationByState(25 * time.Minute)
+	assert.InDelta(t, float64(5*time.Minute), float64(durations["busy"]), tolerance, "Expected partial busy duration within the window")
+	assert.InDelta(t, float64(10*time.Minute), float64(durations["idle"]), tolerance, "Expected idle duration within the window")
+	assert.InDelta(t, float64(10*time.Minute), float64(durations["error"]), tolerance, "Expected error duration within the window")
+}
+
+func TestStateTimerRetention(t *testing.T) {
+	provider := NewProvider()
+	start := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	provider.SetFrozenTime(start)
+
+	// 未設定保留範圍時，查詢較小的視窗不影響之後較大視窗的結果
+	timer := provider.NewStateTimer()
+	timer.Transition("idle")
+	provider.AdvanceMockTime(30 * time.Minute)
+	timer.Transition("busy")
+	provider.AdvanceMockTime(30 * time.Minute)
+	timer.DurationByState(time.Minute)
+	durations := timer.DurationByState(time.Hour)
+	assert.Equal(t, 30*time.Minute, durations["idle"], "Expected earlier queries not to discard history")
+	assert.Equal(t, 30*time.Minute, durations["busy"], "Expected earlier queries not to discard history")
+
+	// 每分鐘轉換一次，持續一天，只需保留最近一小時的紀錄
+	timer = provider.NewStateTimer()
+	timer.SetRetention(time.Hour)
+	states := []string{"idle", "busy"}
+	for i := 0; i <= 24*60; i++ {
+		timer.Transition(states[i%2])
+		provider.AdvanceMockTime(time.Minute)
+	}
+	timer.mu.Lock()
+	retained := len(timer.transitions)
+	timer.mu.Unlock()
+	assert.LessOrEqual(t, retained, 61, "Expected transitions outside the retention to be pruned")
+
+	durations = timer.DurationByState(time.Hour)
+	assert.Equal(t, 30*time.Minute, durations["idle"], "Expected idle duration within the retention after pruning")
+	assert.Equal(t, 30*time.Minute, durations["busy"], "Expected busy duration within the retention after pruning")
+	assert.Panics(t, func() { timer.SetRetention(-time.Minute) }, "Expected negative retention to panic")
+}
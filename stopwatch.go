@@ -0,0 +1,62 @@
+package timeManagement
+
+import (
+	"sync"
+	"time"
+)
+
+// Stopwatch 以時間提供者計時的碼錶，遵循模擬時間及時間加速，可多次啟動及停止並累計時長
+type Stopwatch struct {
+	provider TimeProvider
+	mu       sync.Mutex
+	running  bool
+	started  time.Time
+	elapsed  time.Duration
+}
+
+func (r *realTimeProvider) NewStopwatch() *Stopwatch {
+	return &Stopwatch{provider: r}
+}
+
+// Start 開始計時，已在計時中時不做任何處理
+func (s *Stopwatch) Start() {
+	now := s.provider.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.running {
+		return
+	}
+	s.running = true
+	s.started = now
+}
+
+// Stop 停止計時並累計本次時長，未在計時中時不做任何處理
+func (s *Stopwatch) Stop() {
+	now := s.provider.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.running {
+		return
+	}
+	s.running = false
+	s.elapsed += now.Sub(s.started)
+}
+
+// Reset 停止計時並將累計時長歸零
+func (s *Stopwatch) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.running = false
+	s.elapsed = 0
+}
+
+// Elapsed 返回累計時長，計時中時包含至當前時間為止的時長
+func (s *Stopwatch) Elapsed() time.Duration {
+	now := s.provider.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.running {
+		return s.elapsed + now.Sub(s.started)
+	}
+	return s.elapsed
+}
@@ -0,0 +1,38 @@
+package timeManagement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStopwatch(t *testing.T) {
+	provider := GetProvider()
+	defer provider.ClearMockTime()
+	provider.SetFrozenTime(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	stopwatch := provider.NewStopwatch()
+	assert.Equal(t, time.Duration(0), stopwatch.Elapsed(), "Expected new stopwatch to be zero")
+
+	stopwatch.Start()
+	provider.AdvanceMockTime(90 * time.Second)
+	assert.Equal(t, 90*time.Second, stopwatch.Elapsed(), "Expected elapsed to equal the advanced amount while running")
+
+	stopwatch.Stop()
+	provider.AdvanceMockTime(time.Hour)
+	assert.Equal(t, 90*time.Second, stopwatch.Elapsed(), "Expected stopped stopwatch not to advance")
+
+	// 再次啟動時累計先前的時長，重複 Start 不重設開始時間
+	stopwatch.Start()
+	provider.AdvanceMockTime(10 * time.Second)
+	stopwatch.Start()
+	provider.AdvanceMockTime(20 * time.Second)
+	stopwatch.Stop()
+	stopwatch.Stop()
+	assert.Equal(t, 2*time.Minute, stopwatch.Elapsed(), "Expected elapsed to accumulate across runs")
+
+	stopwatch.Reset()
+	provider.AdvanceMockTime(time.Minute)
+	assert.Equal(t, time.Duration(0), stopwatch.Elapsed(), "Expected reset stopwatch to be zero and stopped")
+}
@@ -0,0 +1,99 @@
+package timeManagement
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// strftimeDirectives 常用 strftime 指令對應的 Go 版面
+var strftimeDirectives = map[byte]string{
+	'Y': "2006",
+	'y': "06",
+	'm': "01",
+	'd': "02",
+	'e': "_2",
+	'j': "002",
+	'H': "15",
+	'I': "03",
+	'M': "04",
+	'S': "05",
+	'p': "PM",
+	'b': "Jan",
+	'h': "Jan",
+	'B': "January",
+	'a': "Mon",
+	'A': "Monday",
+	'Z': "MST",
+	'z': "-0700",
+	'F': "2006-01-02",
+	'T': "15:04:05",
+	'D': "01/02/06",
+	'R': "15:04",
+	'%': "%",
+}
+
+// StrftimeToLayout 將 strftime 格式（如 "%Y-%m-%d %H:%M:%S"）轉換為 Go 參考時間版面
+// %f（微秒）須緊接在 '.' 或 ',' 之後，遇到不支援的指令返回錯誤；
+// 字面文字會原樣放入版面，含有參考時間元件（如 "v2" 中的 "2"）時會被當成指令，因此返回錯誤
+func StrftimeToLayout(pattern string) (string, error) {
+	var b strings.Builder
+	literalStart := 0
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		if c != '%' {
+			b.WriteByte(c)
+			continue
+		}
+		if err := checkStrftimeLiteral(pattern, pattern[literalStart:i]); err != nil {
+			return "", err
+		}
+		if i+1 >= len(pattern) {
+			return "", fmt.Errorf("strftime pattern %q ends with a dangling %%", pattern)
+		}
+		i++
+		literalStart = i + 1
+		directive := pattern[i]
+		if directive == 'f' {
+			out := b.String()
+			if out == "" || (out[len(out)-1] != '.' && out[len(out)-1] != ',') {
+				return "", fmt.Errorf("strftime directive %%f in %q must follow '.' or ','", pattern)
+			}
+			b.WriteString("000000")
+			continue
+		}
+		layout, ok := strftimeDirectives[directive]
+		if !ok {
+			return "", fmt.Errorf("unsupported strftime directive %%%c in %q", directive, pattern)
+		}
+		b.WriteString(layout)
+	}
+	if err := checkStrftimeLiteral(pattern, pattern[literalStart:]); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// checkStrftimeLiteral 檢查 strftime 格式中的字面文字是否含有 Go 參考時間元件
+func checkStrftimeLiteral(pattern, literal string) error {
+	if literal != "" && layoutProbeTime.Format(literal) != literal {
+		return fmt.Errorf("literal text %q in strftime pattern %q contains Go reference time components", literal, pattern)
+	}
+	return nil
+}
+
+func (r *realTimeProvider) ParseStrftime(pattern, value string) (time.Time, error) {
+	layout, err := StrftimeToLayout(pattern)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return r.Parse(layout, value)
+}
+
+func (r *realTimeProvider) FormatStrftime(t time.Time, pattern string) (string, error) {
+	layout, err := StrftimeToLayout(pattern)
+	if err != nil {
+		return "", err
+	}
+	return r.Format(t, layout), nil
+}
@@ -0,0 +1,59 @@
+package timeManagement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrftimeToLayout(t *testing.T) {
+	tests := []struct {
+		pattern string
+		layout  string
+		wantErr bool
+	}{
+		{"%Y-%m-%d %H:%M:%S", DateTimeFormat, false},
+		{"%Y-%m-%d", DateFormat, false},
+		{"%H:%M:%S.%f", "15:04:05.000000", false},
+		{"%a, %d %b %Y %H:%M:%S %z", time.RFC1123Z, false},
+		{"%F %T", DateTimeFormat, false},
+		{"done %%", "done %", false},
+		{"report_%Y-%m-%d", "report_2006-01-02", false},
+		// 字面文字中的 "2"、"1" 在 Go 版面中是日及月
+		{"report_v2_%Y-%m-%d", "", true},
+		{"100%%", "", true},
+		{"%Y Jan", "", true},
+		{"%Q", "", true},
+		{"%Y%", "", true},
+		{"%f", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			layout, err := StrftimeToLayout(tt.pattern)
+			if tt.wantErr {
+				assert.Error(t, err, "Expected pattern %q to be rejected", tt.pattern)
+				return
+			}
+			require.NoError(t, err, "Failed to convert pattern")
+			assert.Equal(t, tt.layout, layout, "Expected layout to match")
+		})
+	}
+}
+
+func TestParseFormatStrftime(t *testing.T) {
+	provider := GetProvider()
+	parsed, err := provider.ParseStrftime("%Y-%m-%d %H:%M:%S", "2023-01-01 12:00:00")
+	require.NoError(t, err, "Failed to parse with strftime pattern")
+	expected := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	assert.True(t, parsed.Equal(expected), "Expected parsed time to match")
+
+	formatted, err := provider.FormatStrftime(expected, "%d/%m/%Y %I:%M %p")
+	require.NoError(t, err, "Failed to format with strftime pattern")
+	assert.Equal(t, "01/01/2023 12:00 PM", formatted, "Expected formatted time to match")
+
+	_, err = provider.FormatStrftime(expected, "%Q")
+	assert.Error(t, err, "Expected unknown directive to error")
+}
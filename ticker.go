@@ -0,0 +1,96 @@
+package timeManagement
+
+import (
+	"sync"
+	"time"
+)
+
+// nextBoundary 返回 t 之後（不含 t）第一個以 loc 當地時間對齊 period 的邊界時間
+// 每個時區區段各以該區段的偏移對齊，日光節約時間切換後的邊界仍落在當地的整點或午夜；
+// 邊界所在的當地時間因切換而不存在時，以切換的時刻為邊界
+func nextBoundary(t time.Time, period time.Duration, loc *time.Location) time.Time {
+	after, zone := t, t
+	for {
+		local := zone.In(loc)
+		_, offset := local.Zone()
+		_, end := local.ZoneBounds()
+		wall := after.UnixNano() + int64(offset)*int64(time.Second)
+		remainder := wall % int64(period)
+		if remainder < 0 {
+			remainder += int64(period)
+		}
+		boundary := after.Add(period - time.Duration(remainder))
+		if end.IsZero() || !boundary.After(end) {
+			return boundary.UTC()
+		}
+		// 邊界超出目前的區段，改以下一個區段的偏移自區段開始（含）重新對齊
+		after, zone = end.Add(-time.Nanosecond), end
+	}
+}
+
+func (r *realTimeProvider) DurationUntilNext(period time.Duration, loc *time.Location) time.Duration {
+	now := r.Now()
+	return nextBoundary(now, period, loc).Sub(now)
+}
+
+func (r *realTimeProvider) TimeCounter(interval time.Duration, epoch time.Time) int64 {
+	if interval <= 0 {
+		panic("Time counter interval must be positive")
+	}
+	return floorDiv(int64(r.Now().Sub(epoch)), int64(interval))
+}
+
+func (r *realTimeProvider) BoundaryTicker(period time.Duration, loc *time.Location) (<-chan time.Time, func()) {
+	if period <= 0 {
+		panic("Boundary ticker period must be positive")
+	}
+	ch := make(chan time.Time, 1)
+	stop := make(chan struct{})
+	// 模擬時間被推進或設置時時鐘會跳動，真實時間的計時器無法察覺，須立即重新檢查
+	jumped := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case jumped <- struct{}{}:
+		default:
+		}
+	}
+	removeListener := r.addClockListener(func(old, new time.Time) { notify() })
+	// 時間加速比例改變時，以新的比例重新計算距離邊界的真實時間
+	removeScaleListener := r.addScaleListener(notify)
+
+	boundary := nextBoundary(r.Now(), period, loc)
+	go func() {
+		defer removeListener()
+		defer removeScaleListener()
+		for {
+			timer := time.NewTimer(r.scaledDuration(boundary.Sub(r.Now())))
+			select {
+			case <-timer.C:
+			case <-jumped:
+				timer.Stop()
+			case <-stop:
+				timer.Stop()
+				return
+			}
+
+			now := r.Now()
+			if now.Before(boundary) {
+				// 計時器可能在模擬時間到達前喚醒，時鐘倒退時改以新的當前時間計算下一個邊界
+				boundary = nextBoundary(now, period, loc)
+				continue
+			}
+			select {
+			case ch <- boundary:
+			default:
+				// 與 time.Ticker 相同，接收端來不及處理時丟棄
+			}
+			// 一次跳過多個邊界時只觸發一次
+			boundary = nextBoundary(now, period, loc)
+		}
+	}()
+
+	var stopOnce sync.Once
+	return ch, func() {
+		stopOnce.Do(func() { close(stop) })
+	}
+}
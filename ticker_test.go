@@ -0,0 +1,115 @@
+package timeManagement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDurationUntilNext(t *testing.T) {
+	provider := GetProvider()
+	location, err := time.LoadLocation("Asia/Taipei")
+	require.NoError(t, err, "Failed to load location")
+	provider.SetMockTime(time.Date(2023, 1, 1, 12, 45, 0, 0, time.UTC))
+	defer provider.ClearMockTime()
+
+	assert.InDelta(t, float64(15*time.Minute), float64(provider.DurationUntilNext(time.Hour, time.UTC)), float64(10*time.Millisecond), "Expected duration until the next hour")
+	// 台北時間為 20:45，距下一個當地午夜 3 小時 15 分
+	assert.InDelta(t, float64(3*time.Hour+15*time.Minute), float64(provider.DurationUntilNext(24*time.Hour, location)), float64(10*time.Millisecond), "Expected duration until the next local midnight")
+
+	// 紐約 2023-03-12 只有 23 小時，00:30 EST 距下一個當地午夜（EDT）22 小時 30 分
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err, "Failed to load location")
+	provider.SetFrozenTime(time.Date(2023, 3, 12, 0, 30, 0, 0, newYork))
+	assert.Equal(t, 22*time.Hour+30*time.Minute, provider.DurationUntilNext(24*time.Hour, newYork), "Expected duration until the local midnight after the DST change")
+	// 秋季切換當天重複的 1 點仍是整點邊界
+	provider.SetFrozenTime(time.Date(2023, 11, 5, 5, 30, 0, 0, time.UTC))
+	assert.Equal(t, 30*time.Minute, provider.DurationUntilNext(time.Hour, newYork), "Expected the repeated local hour to be a boundary")
+}
+
+func TestTimeCounter(t *testing.T) {
+	provider := GetProvider()
+	epoch := time.Unix(0, 0).UTC()
+	defer provider.ClearMockTime()
+
+	// TOTP 30 秒時間步，邊界前後各一秒
+	boundary := time.Unix(1672574400, 0).UTC()
+	provider.SetMockTime(boundary.Add(-time.Second))
+	provider.FreezeNow()
+	before := provider.TimeCounter(30*time.Second, epoch)
+	assert.Equal(t, int64(1672574400/30-1), before, "Expected counter before the boundary")
+
+	provider.SetMockTime(boundary.Add(time.Second))
+	provider.FreezeNow()
+	assert.Equal(t, before+1, provider.TimeCounter(30*time.Second, epoch), "Expected counter to increment by exactly one across the boundary")
+
+	assert.Equal(t, int64(-1), provider.TimeCounter(time.Hour, boundary.Add(30*time.Minute)), "Expected negative counter before epoch")
+	assert.Panics(t, func() { provider.TimeCounter(0, epoch) }, "Expected non-positive interval to panic")
+}
+
+func TestBoundaryTicker(t *testing.T) {
+	provider := GetProvider()
+	period := 20 * time.Millisecond
+	ch, stop := provider.BoundaryTicker(period, time.UTC)
+	defer stop()
+
+	var fires []time.Time
+	for len(fires) < 3 {
+		select {
+		case fired := <-ch:
+			fires = append(fires, fired)
+		case <-time.After(time.Second):
+			require.Fail(t, "Expected boundary ticker to fire")
+		}
+	}
+
+	assert.Zero(t, fires[0].UnixNano()%int64(period), "Expected first fire to land on a boundary")
+	for i := 1; i < len(fires); i++ {
+		assert.Equal(t, 0*time.Millisecond, fires[i].Sub(fires[i-1])%period, "Expected fires to be whole periods apart")
+		assert.True(t, fires[i].After(fires[i-1]), "Expected fires to be increasing")
+	}
+}
+
+func TestBoundaryTickerMockTime(t *testing.T) {
+	provider := NewProvider()
+	provider.SetFrozenTime(time.Date(2023, 1, 1, 10, 0, 30, 0, time.UTC))
+	ch, stop := provider.BoundaryTicker(time.Minute, time.UTC)
+	defer stop()
+
+	select {
+	case fired := <-ch:
+		require.Failf(t, "Expected no fire before the mock clock reaches a boundary", "fired at %v", fired)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	provider.AdvanceMockTime(time.Minute)
+	select {
+	case fired := <-ch:
+		assert.Equal(t, time.Date(2023, 1, 1, 10, 1, 0, 0, time.UTC), fired, "Expected the boundary passed by AdvanceMockTime")
+	case <-time.After(time.Second):
+		require.Fail(t, "Expected boundary ticker to fire when mock time passes a boundary")
+	}
+
+	provider.SetMockTime(time.Date(2023, 1, 1, 10, 5, 0, 0, time.UTC))
+	select {
+	case fired := <-ch:
+		assert.Equal(t, time.Date(2023, 1, 1, 10, 2, 0, 0, time.UTC), fired, "Expected a single fire when several boundaries are skipped")
+	case <-time.After(time.Second):
+		require.Fail(t, "Expected boundary ticker to fire when mock time jumps past a boundary")
+	}
+}
+
+func TestBoundaryTickerStop(t *testing.T) {
+	provider := GetProvider()
+	ch, stop := provider.BoundaryTicker(10*time.Millisecond, time.UTC)
+	stop()
+	stop()
+
+	select {
+	case <-ch:
+		assert.Fail(t, "Expected no fire after stop")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -1,6 +1,9 @@
 package timeManagement
 
 import (
+	"context"
+	"fmt"
+	"math"
 	"sync"
 	"time"
 )
@@ -12,52 +15,218 @@ const (
 	DateTimeFormat      = "2006-01-02 15:04:05"
 	DateTimeFormatTZ    = "2006-01-02T15:04:05Z07:00"
 	DateTimeFormatMilli = "2006-01-02 15:04:05.000"
+	DateTimeFormatMicro = "2006-01-02 15:04:05.000000"
+
+	// 轉出標準函式庫的常用格式，呼叫者不必為了格式字串引入 time
+	RFC3339Format = time.RFC3339
+	RFC1123Format = time.RFC1123
+
+	// ISO 8601 基本格式（不含分隔符號）與延伸格式
+	ISO8601BasicFormat    = "20060102T150405Z0700"
+	ISO8601ExtendedFormat = "2006-01-02T15:04:05.000Z07:00"
+
+	// 緊湊的時間戳格式，常用於檔名
+	CompactTimestampFormat = "20060102150405"
+
+	// 帶時區名稱的日期時間格式
+	DateTimeZoneNameFormat = "2006-01-02 15:04:05 MST"
 )
 
 // TimeProvider 提供所有時間相關的操作介面
 type TimeProvider interface {
 
-	// 返回UTC時間，支持時間加速
+	// 返回UTC時間，支持時間加速；以 SetStripMonotonic(false) 保留單調時鐘讀數時改為返回本地時區的時間
 	Now() time.Time
 
+	// 返回只包含 Now() 的時鐘轉接器，可傳入接受最小時鐘介面的第三方函式庫
+	AsStdClock() Clock
+
+	// 返回記錄每次 Now() 的除錯包裝，時間倒退時觸發 OnRegression 回調
+	WatchMonotonic() *MonotonicWatcher
+
+	// 返回加上指定偏移的當前時間，不改變全局狀態，可模擬時鐘偏移的多台主機
+	NowWithSkew(skew time.Duration) time.Time
+
 	// 返回特定時區的時間
 	NowInZone(location *time.Location) time.Time
 
+	// 返回多個時區的當前時間，任一時區無法載入時返回錯誤
+	NowInZones(zones []string) (map[string]time.Time, error)
+
+	// 以單次 Now() 返回UTC、當地時間、Unix時間戳、RFC 3339 及 ISO 週等表示方式
+	NowAll(loc *time.Location) NowSnapshot
+
 	// 當前時間 - 指定時間
 	Since(t time.Time) time.Duration
 
 	// 指定時間 - 當前時間
 	Until(t time.Time) time.Duration
 
+	// 返回時間戳的年齡及是否仍在 maxAge 內，未來的時間戳年齡為負數且視為新鮮
+	Freshness(t time.Time, maxAge time.Duration) (age time.Duration, fresh bool)
+
+	// 返回排程時間已延遲的時長，尚未到期時返回 0
+	Lateness(scheduled time.Time) time.Duration
+
+	// 返回當前時間與截止時間的中點，截止時間已過時返回當前時間
+	MidpointToDeadline(deadline time.Time) time.Time
+
+	// 返回距離可再次呼叫（上次呼叫 + minInterval）的時間，已可呼叫時返回 0
+	DurationUntilAvailable(lastCall time.Time, minInterval time.Duration) time.Duration
+
+	// 依開始時間與完成比例推算預計完成時間，完成比例非正數時返回 false
+	EstimateCompletion(start time.Time, fractionDone float64) (time.Time, bool)
+
+	// 依目前數值與每秒變化率推算數值到達目標的時間，變化方向遠離目標時返回 false
+	ProjectCrossing(current, target, ratePerSecond float64) (time.Time, bool)
+
+	// 返回距離指定時間的倒數字串，如 "2d 3h"，已到達或已過時返回 "now" 或 "past"
+	CountdownString(until time.Time) string
+
+	// 以當前時間為基準粗略描述時間，例如 "3 minutes ago" 或 "in 2 hours"，相差不到一秒時為 "just now"
+	Humanize(t time.Time) string
+
+	// 返回相對時間及指定時區的絕對時間，如 "5 minutes ago (2023-01-01 12:00)"
+	FormatRelativeAbsolute(t time.Time, loc *time.Location, layout string) string
+
 	// 睡眠指定時間，支持時間加速
 	Sleep(d time.Duration)
 
+	// 睡眠指定時間，支持時間加速，上下文先結束時提前返回 ctx.Err()
+	SleepContext(ctx context.Context, d time.Duration) error
+
+	// 以 seed 產生介於 [0, maxDelay] 的隨機延遲並睡眠，支持時間加速，返回延遲時長
+	SleepSmoothStart(maxDelay time.Duration, seed int64) time.Duration
+
 	// 返回一個通道，指定時間後會發送一個時間，支持時間加速
 	After(d time.Duration) <-chan time.Time
 
+	// 自當前時間起每隔 step 檢查一次（以 loc 當地時間對齊 step），返回 horizon 內第一個符合 matcher 的時間，matcher 接收 loc 時區的時間
+	NextMatching(matcher func(time.Time) bool, step time.Duration, horizon time.Duration, loc *time.Location) (time.Time, bool)
+
+	// 依 anchor 起以 onDuration 開啟、offDuration 關閉循環的週期，返回當前是否為開啟階段及距離切換的時間
+	DutyCyclePhase(anchor time.Time, onDuration, offDuration time.Duration) (on bool, remaining time.Duration)
+
+	// 建立一個可停止及重設的計時器，支持時間加速，觸發時發送時間提供者的當前時間
+	NewTimer(d time.Duration) *ScaledTimer
+
+	// 經過指定時間後於獨立協程執行 f，支持時間加速，返回可停止的計時器
+	AfterFunc(d time.Duration, f func()) *ScaledTimer
+
+	// 返回經過指定時間後逾時的上下文，計時支持時間加速
+	WithTimeout(parent context.Context, d time.Duration) (context.Context, context.CancelFunc)
+
+	// 返回到達指定時間後逾時的上下文，Deadline() 返回時間提供者時間軸上的截止時間
+	WithDeadline(parent context.Context, deadline time.Time) (context.Context, context.CancelFunc)
+
+	// 建立支持時間加速的週期觸發器，運行中改變時間加速比例會套用於之後的觸發
+	NewTicker(d time.Duration) *ScaledTicker
+
+	// 返回距離下一個以指定時區當地時間對齊週期的邊界的時間，日光節約時間切換後仍對齊當地的整點或午夜
+	DurationUntilNext(period time.Duration, loc *time.Location) time.Duration
+
+	// 返回一個在每個對齊週期的邊界發送邊界時間的通道及停止函數，支持時間加速，模擬時間被推進越過邊界時立即觸發
+	BoundaryTicker(period time.Duration, loc *time.Location) (<-chan time.Time, func())
+
+	// 返回自 epoch 起經過的完整 interval 數，如 TOTP 的時間步數，早於 epoch 時為負數
+	TimeCounter(interval time.Duration, epoch time.Time) int64
+
+	// 建立以此時間提供者計時的狀態計時器
+	NewStateTimer() *StateTimer
+
+	// 建立每次活動時延長 ttl 的截止時間，以 Touch() 記錄活動
+	NewSlidingDeadline(ttl time.Duration) *SlidingDeadline
+
+	// 建立以此時間提供者判斷當前時間的營業日曆，workStart 與 workEnd 為自當地午夜起算的上下班時間
+	NewBusinessCalendar(loc *time.Location, workStart, workEnd time.Duration) *BusinessCalendar
+
+	// 建立以此時間提供者計時的碼錶
+	NewStopwatch() *Stopwatch
+
+	// 建立停機時段清單，以 InDowntime() 判斷當前時間是否位於停機時段內
+	NewDowntimeSchedule() *DowntimeSchedule
+
 	// 解析時間字符串，返回UTC時間
 	Parse(layout, value string) (time.Time, error)
 
+	// 設置 Parse 解析不含時區資訊的字符串時使用的時區，預設為UTC
+	SetParseDefaultLocation(loc *time.Location)
+
 	// 解析指定時區的時間字符串，返回UTC時間
 	ParseInLocation(layout, value string, loc *time.Location) (time.Time, error)
 
+	// 解析 "start/end" 格式的時間範圍，兩端以指定時區解析並返回UTC時間
+	ParseRange(s string, layout string, loc *time.Location) (start, end time.Time, err error)
+
 	// 格式化時間為字符串
 	Format(t time.Time, layout string) string
 
+	// 將時間轉換為固定寬度且可依字典序排序的鍵
+	SortableKey(t time.Time) string
+
+	// 解析 SortableKey 產生的鍵，返回UTC時間
+	ParseSortableKey(key string) (time.Time, error)
+
+	// 格式化為 RFC 2822 郵件 Date 標頭格式，使用指定時區的偏移
+	FormatEmailDate(t time.Time, loc *time.Location) string
+
+	// 解析 RFC 2822 郵件 Date 標頭，返回UTC時間
+	ParseEmailDate(value string) (time.Time, error)
+
+	// 使用 strftime 格式解析時間字符串，返回UTC時間
+	ParseStrftime(pattern, value string) (time.Time, error)
+
+	// 使用 strftime 格式格式化時間為字符串
+	FormatStrftime(t time.Time, pattern string) (string, error)
+
 	// 將任何時間轉換為UTC
 	UTC(t time.Time) time.Time
 
 	// 將UTC時間轉換為指定時區
 	In(t time.Time, location *time.Location) time.Time
 
+	// 以 t 本身的時區加上年、月、日後轉換為UTC，日期溢位時與標準函式庫相同會正規化，例如 1 月 31 日加一個月為 3 月 3 日（平年）
+	AddDate(t time.Time, years, months, days int) time.Time
+
+	// 將時間四捨五入至 d 的整數倍並返回UTC，與標準函式庫相同以零時間（西元 1 年）為基準，中間值進位
+	Round(t time.Time, d time.Duration) time.Time
+
+	// 將時間向下取整至 d 的整數倍並返回UTC，與標準函式庫相同以零時間（西元 1 年）為基準
+	Truncate(t time.Time, d time.Duration) time.Time
+
 	// 將時間轉換為Unix時間戳
 	Unix(t time.Time) int64
 
 	// 將時間轉換為Unix毫秒時間戳
 	UnixMilli(t time.Time) int64
 
-	// 設置時間加速比例
-	SetTimeScale(scale float64)
+	// 將時間轉換為Unix微秒時間戳
+	UnixMicro(t time.Time) int64
+
+	// 將時間轉換為Unix奈秒時間戳
+	UnixNano(t time.Time) int64
+
+	// 將Unix時間戳轉換為UTC時間
+	FromUnix(sec int64) time.Time
+
+	// 將Unix毫秒時間戳轉換為UTC時間
+	FromUnixMilli(msec int64) time.Time
+
+	// 將Unix奈秒時間戳轉換為UTC時間
+	FromUnixNano(nsec int64) time.Time
+
+	// 將Unix時間戳批次轉換為UTC時間
+	FromUnixBatch(secs []int64) []time.Time
+
+	// 將Unix毫秒時間戳批次轉換為UTC時間
+	FromUnixMilliBatch(msecs []int64) []time.Time
+
+	// 設置時間加速比例，0 表示凍結時間直到以 ClearTimeScale 或其他比例恢復，計時器及睡眠依剩餘時長以新的比例繼續；
+	// 負數、NaN 或無限大時返回錯誤並保留原本的比例，適用於來自設定檔等不可信的輸入
+	SetTimeScale(scale float64) error
+
+	// 與 SetTimeScale 相同，但比例無效時 panic
+	MustSetTimeScale(scale float64)
 
 	// 獲取時間加速比例
 	GetTimeScale() float64
@@ -70,16 +239,69 @@ type TimeProvider interface {
 
 	// 清除模擬時間
 	ClearMockTime()
+
+	// 恢復為剛建立時的預設狀態，清除模擬時間、時間加速及其他設定
+	Reset()
+
+	// 將模擬時間往前推進指定時長，未設置模擬時間時無效果。
+	// 推進後時鐘進入逐步模式，不再隨真實時間前進，因此 SetMockTime(t) 後推進 d 精確得到 t+d，
+	// 設置後至第一次推進前經過的真實時間不計入
+	AdvanceMockTime(d time.Duration)
+
+	// 將時鐘固定在當前時間，不再前進，返回固定的時間
+	FreezeNow() time.Time
+
+	// 將時鐘固定在指定時間，不隨真實時間或時間加速前進，直到清除或以 AdvanceMockTime 推進
+	SetFrozenTime(t time.Time)
+
+	// 暫停時鐘，Now() 固定返回暫停時的時間，適用於真實時間、時間加速及模擬時間；已暫停時無效果
+	// 暫停期間仍可以 SetMockTime 或 AdvanceMockTime 調整時間，計時器及睡眠不受影響
+	Pause()
+
+	// 恢復暫停的時鐘，從暫停時的時間繼續前進，暫停的期間不計入；未暫停時無效果
+	Resume()
+
+	// 註冊時鐘跳動監聽器，SetMockTime、ClearMockTime、AdvanceMockTime、SetFrozenTime、Reset
+	// 或時間加速比例的變更使 Now() 不連續跳動時，於狀態更新後依註冊順序同步呼叫，old 與 new 為跳動前後的UTC時間；
+	// 監聽器內可呼叫時間提供者的方法
+	RegisterClockChangeListener(listener func(old, new time.Time))
+
+	// 設置 Now() 是否去除單調時鐘讀數（預設去除）。UTC() 一定會去除單調時鐘讀數，
+	// 因此保留時 Now() 返回本地時區（time.Local）的時間而非UTC時間，需要UTC時呼叫端須自行轉換並放棄單調時鐘讀數
+	SetStripMonotonic(strip bool)
+	// 將目前的模擬時間與時間加速設定編碼為環境變數，供子行程使用；凍結、逐步推進或暫停中的時鐘以凍結的時間傳遞
+	// 將目前的模擬時間與時間加速設定編碼為環境變數，供子行程使用
+	EnvFromState() []string
 }
 
 type realTimeProvider struct {
 	mockTime      *time.Time
 	mockStartTime time.Time
 	mockBaseTime  time.Time
+	mockFrozen    bool
 	mockTimeLock  sync.RWMutex
 	timeScale     float64
 	baseTime      time.Time
 	scaleStart    time.Time
+	keepMonotonic bool
+	parseLocation *time.Location
+	paused        bool
+	pauseStart    time.Time
+	pausedTotal   time.Duration
+	// 時鐘跳動監聽器只會附加或整個替換，呼叫者可在持有鎖時複製切片後於鎖外呼叫
+	clockListeners []*clockListener
+	// 時間加速比例變更監聽器，供計時器重新換算剩餘的真實等待時間，與 clockListeners 相同只會附加或整個替換
+	scaleListeners []*scaleListener
+}
+
+// clockListener 以指標識別的時鐘跳動監聽器，供內部移除
+type clockListener struct {
+	f func(old, new time.Time)
+}
+
+// scaleListener 以指標識別的時間加速比例變更監聽器，供內部移除
+type scaleListener struct {
+	f func()
 }
 
 var (
@@ -90,33 +312,100 @@ var (
 // GetProvider 返回 TimeProvider 的單例實例
 func GetProvider() TimeProvider {
 	once.Do(func() {
-		instance = &realTimeProvider{
-			timeScale: 1.0,
-		}
+		instance = newRealTimeProvider()
 	})
 	return instance
 }
 
+// NewProvider 返回一個擁有獨立狀態的 TimeProvider，可注入至結構中並在每個測試各自設定模擬時間
+func NewProvider() TimeProvider {
+	return newRealTimeProvider()
+}
+
+// newRealTimeProvider 建立一個使用預設狀態的 realTimeProvider
+func newRealTimeProvider() *realTimeProvider {
+	return &realTimeProvider{
+		timeScale: 1.0,
+	}
+}
+
 func (r *realTimeProvider) Now() time.Time {
+	offset, _ := serverTimeOffset()
+	now := time.Now()
+
 	r.mockTimeLock.RLock()
 	defer r.mockTimeLock.RUnlock()
 
-	if r.mockTime != nil {
+	current := r.currentLocked(now, offset)
+	if r.keepMonotonic {
+		// UTC() 會去除單調時鐘讀數，因此保留模式返回本地時區的時間
+		return now.Add(current.Sub(now))
+	}
+	return current.UTC()
+}
+
+// currentLocked 依模擬時間及時間加速設定，返回真實時間 now 對應的時間，呼叫者須持有 mockTimeLock
+// serverOffset 為伺服器時間相對本地時鐘的偏移，只套用於未模擬的時鐘
+func (r *realTimeProvider) currentLocked(now time.Time, serverOffset time.Duration) time.Time {
+	now = r.clockLocked(now)
+	current := now.Add(serverOffset)
+	if r.mockTime != nil && r.mockFrozen {
+		current = r.mockBaseTime
+	} else if r.mockTime != nil {
 		// 計算從設置模擬時間開始經過的時間
-		elapsed := time.Since(r.mockStartTime)
+		elapsed := now.Sub(r.mockStartTime)
 		if r.timeScale != 1.0 {
 			elapsed = time.Duration(float64(elapsed) * r.timeScale)
 		}
-		return r.mockBaseTime.Add(elapsed).UTC()
+		current = r.mockBaseTime.Add(elapsed)
+	} else if r.timeScale != 1.0 {
+		realElapsed := now.Sub(r.scaleStart)
+		scaledElapsed := time.Duration(float64(realElapsed) * r.timeScale)
+		current = r.baseTime.Add(scaledElapsed)
 	}
+	return current
+}
 
-	if r.timeScale != 1.0 {
-		realElapsed := time.Since(r.scaleStart)
-		scaledElapsed := time.Duration(float64(realElapsed) * r.timeScale)
-		return r.baseTime.Add(scaledElapsed).UTC()
+// clockLocked 返回扣除暫停時長後的真實時間 now，暫停中時固定為暫停的時刻，呼叫者須持有 mockTimeLock
+// 所有依真實時間推進的基準（模擬時間及時間加速）皆以此時鐘記錄，因此暫停對任何時間來源皆有效
+func (r *realTimeProvider) clockLocked(now time.Time) time.Time {
+	if r.paused {
+		now = r.pauseStart
+	}
+	return now.Add(-r.pausedTotal)
+}
+
+// changeClock 在寫入鎖內以同一個真實時間 now 執行 update，時間加速比例改變時先呼叫比例變更監聽器，
+// 更新後的時間與更新前不同時，於釋放鎖後依註冊順序同步呼叫時鐘跳動監聽器
+func (r *realTimeProvider) changeClock(update func(now time.Time)) {
+	offset, _ := serverTimeOffset()
+
+	r.mockTimeLock.Lock()
+	now := time.Now()
+	old := r.currentLocked(now, offset)
+	oldScale := r.timeScale
+	update(now)
+	current := r.currentLocked(now, offset)
+	listeners := r.clockListeners
+	var scaleListeners []*scaleListener
+	if r.timeScale != oldScale {
+		scaleListeners = r.scaleListeners
 	}
+	r.mockTimeLock.Unlock()
 
-	return time.Now().UTC()
+	for _, listener := range scaleListeners {
+		listener.f()
+	}
+	if old.Equal(current) {
+		return
+	}
+	for _, listener := range listeners {
+		listener.f(old.UTC(), current.UTC())
+	}
+}
+
+func (r *realTimeProvider) NowWithSkew(skew time.Duration) time.Time {
+	return r.Now().Add(skew)
 }
 
 func (r *realTimeProvider) NowInZone(location *time.Location) time.Time {
@@ -132,30 +421,46 @@ func (r *realTimeProvider) Until(t time.Time) time.Duration {
 }
 
 func (r *realTimeProvider) Sleep(d time.Duration) {
-	if r.timeScale != 1.0 {
-		adjustedDuration := time.Duration(float64(d) / r.timeScale)
-		time.Sleep(adjustedDuration)
-		return
+	r.sleepScaled(d, nil)
+}
+
+func (r *realTimeProvider) SleepContext(ctx context.Context, d time.Duration) error {
+	if !r.sleepScaled(d, ctx.Done()) {
+		return ctx.Err()
 	}
-	time.Sleep(d)
+	return nil
 }
 
 func (r *realTimeProvider) After(d time.Duration) <-chan time.Time {
-	if r.timeScale != 1.0 {
-		adjustedDuration := time.Duration(float64(d) / r.timeScale)
-		return time.After(adjustedDuration)
-	}
-	return time.After(d)
+	c := make(chan time.Time, 1)
+	go func() {
+		r.sleepScaled(d, nil)
+		c <- time.Now()
+	}()
+	return c
 }
 
 func (r *realTimeProvider) Parse(layout, value string) (time.Time, error) {
-	t, err := time.Parse(layout, value)
+	r.mockTimeLock.RLock()
+	loc := r.parseLocation
+	r.mockTimeLock.RUnlock()
+
+	if loc == nil {
+		loc = time.UTC
+	}
+	t, err := time.ParseInLocation(layout, value, loc)
 	if err != nil {
 		return time.Time{}, err
 	}
 	return t.UTC(), nil
 }
 
+func (r *realTimeProvider) SetParseDefaultLocation(loc *time.Location) {
+	r.mockTimeLock.Lock()
+	defer r.mockTimeLock.Unlock()
+	r.parseLocation = loc
+}
+
 func (r *realTimeProvider) ParseInLocation(layout, value string, loc *time.Location) (time.Time, error) {
 	t, err := time.ParseInLocation(layout, value, loc)
 	if err != nil {
@@ -176,6 +481,18 @@ func (r *realTimeProvider) In(t time.Time, location *time.Location) time.Time {
 	return t.In(location)
 }
 
+func (r *realTimeProvider) AddDate(t time.Time, years, months, days int) time.Time {
+	return t.AddDate(years, months, days).UTC()
+}
+
+func (r *realTimeProvider) Round(t time.Time, d time.Duration) time.Time {
+	return t.Round(d).UTC()
+}
+
+func (r *realTimeProvider) Truncate(t time.Time, d time.Duration) time.Time {
+	return t.Truncate(d).UTC()
+}
+
 func (r *realTimeProvider) Unix(t time.Time) int64 {
 	return t.UTC().Unix()
 }
@@ -184,26 +501,41 @@ func (r *realTimeProvider) UnixMilli(t time.Time) int64 {
 	return t.UTC().UnixMilli()
 }
 
-func (r *realTimeProvider) SetTimeScale(scale float64) {
-	if scale <= 0 {
-		panic("Time scale must be positive")
-	}
-	// 先獲取當前時間
-	currentTime := r.Now()
+func (r *realTimeProvider) UnixMicro(t time.Time) int64 {
+	return t.UTC().UnixMicro()
+}
 
-	r.mockTimeLock.Lock()
-	defer r.mockTimeLock.Unlock()
+func (r *realTimeProvider) UnixNano(t time.Time) int64 {
+	return t.UTC().UnixNano()
+}
 
-	if r.mockTime != nil {
-		// 更新模擬時間的基準時間和開始時間
-		elapsed := time.Since(r.mockStartTime)
-		r.mockBaseTime = r.mockBaseTime.Add(elapsed)
-		r.mockStartTime = time.Now()
+func (r *realTimeProvider) SetTimeScale(scale float64) error {
+	if scale < 0 || math.IsNaN(scale) || math.IsInf(scale, 0) {
+		return fmt.Errorf("invalid time scale %v: must be a finite non-negative number", scale)
 	}
+	offset, _ := serverTimeOffset()
+
+	// 在同一把鎖內以舊的比例計算當前時間再切換，避免與其他呼叫交錯造成時間跳動
+	r.changeClock(func(now time.Time) {
+		current := r.currentLocked(now, offset)
+		now = r.clockLocked(now)
+		if r.mockTime != nil && !r.mockFrozen {
+			// 更新模擬時間的基準時間和開始時間
+			r.mockBaseTime = current
+			r.mockStartTime = now
+		}
 
-	r.baseTime = currentTime
-	r.scaleStart = time.Now().UTC()
-	r.timeScale = scale
+		r.baseTime = current
+		r.scaleStart = now
+		r.timeScale = scale
+	})
+	return nil
+}
+
+func (r *realTimeProvider) MustSetTimeScale(scale float64) {
+	if err := r.SetTimeScale(scale); err != nil {
+		panic(err)
+	}
 }
 
 func (r *realTimeProvider) GetTimeScale() float64 {
@@ -217,18 +549,143 @@ func (r *realTimeProvider) ClearTimeScale() {
 }
 
 func (r *realTimeProvider) SetMockTime(t time.Time) {
-	r.mockTimeLock.Lock()
-	defer r.mockTimeLock.Unlock()
+	r.changeClock(func(now time.Time) {
+		utcTime := t.UTC()
+		r.mockBaseTime = utcTime
+		r.mockStartTime = r.clockLocked(now)
+		r.mockTime = &utcTime
+		r.mockFrozen = false
+		r.timeScale = 1.0
+	})
+}
+
+func (r *realTimeProvider) ClearMockTime() {
+	r.changeClock(func(time.Time) {
+		r.mockTime = nil
+		r.mockFrozen = false
+		r.timeScale = 1.0
+	})
+}
+
+func (r *realTimeProvider) Reset() {
+	// 時鐘跳動監聽器為訂閱而非設定，重設時保留
+	r.changeClock(func(time.Time) {
+		r.mockTime = nil
+		r.mockStartTime = time.Time{}
+		r.mockBaseTime = time.Time{}
+		r.mockFrozen = false
+		r.timeScale = 1.0
+		r.baseTime = time.Time{}
+		r.scaleStart = time.Time{}
+		r.keepMonotonic = false
+		r.parseLocation = nil
+		r.paused = false
+		r.pauseStart = time.Time{}
+		r.pausedTotal = 0
+	})
+}
+
+func (r *realTimeProvider) AdvanceMockTime(d time.Duration) {
+	r.changeClock(func(time.Time) {
+		if r.mockTime == nil {
+			return
+		}
+		r.mockBaseTime = r.mockBaseTime.Add(d)
+		r.mockFrozen = true
+	})
+}
+
+func (r *realTimeProvider) FreezeNow() time.Time {
+	offset, _ := serverTimeOffset()
+	var frozen time.Time
+	// 在同一把鎖內讀取並固定當前時間，時鐘不會跳動，因此不觸發監聽器
+	r.changeClock(func(now time.Time) {
+		frozen = r.currentLocked(now, offset).UTC()
+		r.freezeLocked(frozen, now)
+	})
+	return frozen
+}
+
+func (r *realTimeProvider) SetFrozenTime(t time.Time) {
+	r.changeClock(func(now time.Time) {
+		r.freezeLocked(t, now)
+	})
+}
+
+// freezeLocked 將時鐘固定在 t，呼叫者須持有 mockTimeLock
+func (r *realTimeProvider) freezeLocked(t time.Time, now time.Time) {
 	utcTime := t.UTC()
 	r.mockBaseTime = utcTime
-	r.mockStartTime = time.Now()
+	r.mockStartTime = r.clockLocked(now)
 	r.mockTime = &utcTime
-	r.timeScale = 1.0
+	r.mockFrozen = true
 }
 
-func (r *realTimeProvider) ClearMockTime() {
+func (r *realTimeProvider) RegisterClockChangeListener(listener func(old, new time.Time)) {
+	r.addClockListener(listener)
+}
+
+// addClockListener 註冊時鐘跳動監聽器，返回移除該監聽器的函數
+func (r *realTimeProvider) addClockListener(f func(old, new time.Time)) func() {
+	listener := &clockListener{f: f}
+	r.mockTimeLock.Lock()
+	defer r.mockTimeLock.Unlock()
+	r.clockListeners = append(r.clockListeners, listener)
+
+	return func() {
+		r.mockTimeLock.Lock()
+		defer r.mockTimeLock.Unlock()
+		r.clockListeners = withoutListener(r.clockListeners, listener)
+	}
+}
+
+// addScaleListener 註冊時間加速比例變更監聽器，f 於比例改變後在鎖外同步呼叫，返回移除該監聽器的函數
+func (r *realTimeProvider) addScaleListener(f func()) func() {
+	listener := &scaleListener{f: f}
+	r.mockTimeLock.Lock()
+	defer r.mockTimeLock.Unlock()
+	r.scaleListeners = append(r.scaleListeners, listener)
+
+	return func() {
+		r.mockTimeLock.Lock()
+		defer r.mockTimeLock.Unlock()
+		r.scaleListeners = withoutListener(r.scaleListeners, listener)
+	}
+}
+
+// withoutListener 返回移除 listener 後的新切片，不影響其他呼叫已複製的切片
+func withoutListener[T comparable](listeners []T, listener T) []T {
+	remaining := make([]T, 0, len(listeners))
+	for _, l := range listeners {
+		if l != listener {
+			remaining = append(remaining, l)
+		}
+	}
+	return remaining
+}
+
+func (r *realTimeProvider) Pause() {
+	r.mockTimeLock.Lock()
+	defer r.mockTimeLock.Unlock()
+	if r.paused {
+		return
+	}
+	r.paused = true
+	r.pauseStart = time.Now()
+}
+
+func (r *realTimeProvider) Resume() {
+	r.mockTimeLock.Lock()
+	defer r.mockTimeLock.Unlock()
+	if !r.paused {
+		return
+	}
+	r.paused = false
+	r.pausedTotal += time.Since(r.pauseStart)
+}
+
+func (r *realTimeProvider) SetStripMonotonic(strip bool) {
 	r.mockTimeLock.Lock()
 	defer r.mockTimeLock.Unlock()
-	r.mockTime = nil
-	r.timeScale = 1.0
+	r.keepMonotonic = !strip
 }
@@ -1,6 +1,9 @@
 package timeManagement
 
 import (
+	"context"
+	"math"
+	"sync"
 	"testing"
 	"time"
 
@@ -8,12 +11,53 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+func TestFormatConstants(t *testing.T) {
+	known := time.Date(2023, 1, 2, 15, 4, 5, 123000000, time.UTC)
+
+	tests := []struct {
+		name      string
+		layout    string
+		formatted string
+		expected  time.Time
+	}{
+		{"RFC3339Format", RFC3339Format, "2023-01-02T15:04:05Z", known.Truncate(time.Second)},
+		{"RFC1123Format", RFC1123Format, "Mon, 02 Jan 2023 15:04:05 UTC", known.Truncate(time.Second)},
+		{"ISO8601BasicFormat", ISO8601BasicFormat, "20230102T150405Z", known.Truncate(time.Second)},
+		{"ISO8601ExtendedFormat", ISO8601ExtendedFormat, "2023-01-02T15:04:05.123Z", known},
+		{"CompactTimestampFormat", CompactTimestampFormat, "20230102150405", known.Truncate(time.Second)},
+		{"DateTimeZoneNameFormat", DateTimeZoneNameFormat, "2023-01-02 15:04:05 UTC", known.Truncate(time.Second)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formatted := known.Format(tt.layout)
+			assert.Equal(t, tt.formatted, formatted, "Expected formatted value to match")
+			parsed, err := time.Parse(tt.layout, formatted)
+			require.NoError(t, err, "Failed to parse formatted value")
+			assert.True(t, tt.expected.Equal(parsed), "Expected %v, got %v", tt.expected, parsed)
+		})
+	}
+}
+
 func TestNow(t *testing.T) {
 	provider := GetProvider()
 	now := provider.Now()
 	assert.False(t, now.IsZero(), "Expected non-zero time")
 }
 
+func TestNowWithSkew(t *testing.T) {
+	provider := GetProvider()
+	mockTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	provider.SetMockTime(mockTime)
+	defer provider.ClearMockTime()
+
+	for _, skew := range []time.Duration{-2 * time.Second, 0, 5 * time.Minute} {
+		skewed := provider.NowWithSkew(skew)
+		assert.WithinDuration(t, mockTime.Add(skew), skewed, 10*time.Millisecond, "Expected skewed time to include offset %v", skew)
+		assert.WithinDuration(t, mockTime, provider.Now(), 10*time.Millisecond, "Expected base time to stay unchanged")
+	}
+}
+
 func TestNowInZone(t *testing.T) {
 	provider := GetProvider()
 	location, err := time.LoadLocation("America/New_York")
@@ -60,6 +104,33 @@ func TestSleep(t *testing.T) {
 	}
 }
 
+func TestSleepContext(t *testing.T) {
+	provider := GetProvider()
+	provider.SetTimeScale(10.0)
+	defer provider.ClearTimeScale()
+
+	start := time.Now()
+	err := provider.SleepContext(context.Background(), 200*time.Millisecond)
+	assert.NoError(t, err, "Expected sleep to complete")
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond, "Expected sleep to wait the scaled duration")
+	assert.Less(t, time.Since(start), 150*time.Millisecond, "Expected sleep to honor the time scale")
+}
+
+func TestSleepContextCanceled(t *testing.T) {
+	provider := GetProvider()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	start := time.Now()
+	err := provider.SleepContext(ctx, time.Hour)
+	assert.ErrorIs(t, err, context.Canceled, "Expected cancellation error")
+	assert.Less(t, time.Since(start), 500*time.Millisecond, "Expected sleep to return promptly after cancellation")
+}
+
 func TestAfter(t *testing.T) {
 	provider := GetProvider()
 
@@ -97,6 +168,27 @@ func TestParse(t *testing.T) {
 	assert.True(t, parsedTime.Equal(expectedTime.UTC()), "Expected parsed time to match")
 }
 
+func TestSetParseDefaultLocation(t *testing.T) {
+	provider := GetProvider()
+	location, err := time.LoadLocation("Asia/Taipei")
+	require.NoError(t, err, "Failed to load location")
+	provider.SetParseDefaultLocation(location)
+	defer provider.SetParseDefaultLocation(nil)
+
+	parsedTime, err := provider.Parse(DateTimeFormat, "2023-01-01 12:00:00")
+	require.NoError(t, err, "Failed to parse time")
+	assert.Equal(t, time.Date(2023, 1, 1, 4, 0, 0, 0, time.UTC), parsedTime, "Expected zoneless input to be interpreted in the default location")
+
+	parsedTime, err = provider.Parse(DateTimeFormatTZ, "2023-01-01T12:00:00Z")
+	require.NoError(t, err, "Failed to parse time")
+	assert.Equal(t, time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC), parsedTime, "Expected explicit zone to take precedence")
+
+	key := provider.SortableKey(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	parsedTime, err = provider.ParseSortableKey(key)
+	require.NoError(t, err, "Failed to parse sortable key")
+	assert.Equal(t, time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC), parsedTime, "Expected sortable keys to stay UTC")
+}
+
 func TestParseInLocation(t *testing.T) {
 	provider := GetProvider()
 	layout := DateTimeFormat
@@ -119,6 +211,21 @@ func TestFormat(t *testing.T) {
 	assert.True(t, parsedTime.Equal(parseNow.UTC()), "Expected formatted time to match")
 }
 
+func TestFormatMicro(t *testing.T) {
+	provider := GetProvider()
+	location, err := time.LoadLocation("Asia/Taipei")
+	require.NoError(t, err, "Failed to load location")
+
+	value := time.Date(2023, 1, 2, 23, 4, 5, 123456789, location)
+	formatted := provider.Format(value, DateTimeFormatMicro)
+	assert.Equal(t, "2023-01-02 15:04:05.123456", formatted, "Expected microsecond UTC format")
+
+	parsed, err := provider.Parse(DateTimeFormatMicro, formatted)
+	require.NoError(t, err, "Failed to parse microsecond format")
+	assert.True(t, value.Truncate(time.Microsecond).Equal(parsed), "Expected the same instant at microsecond precision")
+	assert.Equal(t, time.UTC, parsed.Location(), "Expected UTC time")
+}
+
 func TestUTC(t *testing.T) {
 	provider := GetProvider()
 	now := provider.Now()
@@ -135,6 +242,62 @@ func TestIn(t *testing.T) {
 	assert.Equal(t, location, inTime.Location(), "Expected location to match")
 }
 
+func TestAddDate(t *testing.T) {
+	provider := GetProvider()
+	location, err := time.LoadLocation("Asia/Taipei")
+	require.NoError(t, err, "Failed to load location")
+
+	tests := []struct {
+		name                string
+		t                   time.Time
+		years, months, days int
+		expected            time.Time
+	}{
+		{"Leap day plus one year", time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC), 1, 0, 0, time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)},
+		{"Leap day plus four years", time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC), 4, 0, 0, time.Date(2028, 2, 29, 12, 0, 0, 0, time.UTC)},
+		{"Month overflow", time.Date(2023, 1, 31, 12, 0, 0, 0, time.UTC), 0, 1, 0, time.Date(2023, 3, 3, 12, 0, 0, 0, time.UTC)},
+		{"Negative days", time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC), 0, 0, -1, time.Date(2023, 2, 28, 12, 0, 0, 0, time.UTC)},
+		// 以當地日期計算：台北 2 月 1 日 02:00 加一個月為 3 月 1 日 02:00
+		{"Calendar in own location", time.Date(2023, 2, 1, 2, 0, 0, 0, location), 0, 1, 0, time.Date(2023, 2, 28, 18, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := provider.AddDate(tt.t, tt.years, tt.months, tt.days)
+			assert.Equal(t, tt.expected, result, "Expected date to match standard library semantics")
+			assert.Equal(t, time.UTC, result.Location(), "Expected UTC time")
+		})
+	}
+}
+
+func TestRoundAndTruncate(t *testing.T) {
+	provider := GetProvider()
+	location, err := time.LoadLocation("Asia/Taipei")
+	require.NoError(t, err, "Failed to load location")
+
+	base := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, base.Add(2*time.Minute), provider.Round(base.Add(90*time.Second), time.Minute), "Expected 90 seconds to round up to 2 minutes")
+	assert.Equal(t, base.Add(time.Minute), provider.Round(base.Add(89*time.Second), time.Minute), "Expected 89 seconds to round down to 1 minute")
+
+	quarter := 15 * time.Minute
+	assert.Equal(t, base.Add(30*time.Minute), provider.Truncate(base.Add(44*time.Minute+59*time.Second), quarter), "Expected 15-minute bucket start")
+	assert.Equal(t, base.Add(45*time.Minute), provider.Truncate(base.Add(45*time.Minute), quarter), "Expected bucket boundary to stay")
+
+	local := provider.Truncate(time.Date(2023, 1, 1, 20, 7, 0, 0, location), quarter)
+	assert.Equal(t, time.UTC, local.Location(), "Expected UTC time")
+	assert.Equal(t, base, local, "Expected local time to be truncated and normalized to UTC")
+
+	// Unix 紀元前的時間同樣以零時間為基準
+	beforeEpoch := time.Date(1969, 12, 31, 23, 59, 30, 0, time.UTC)
+	assert.Equal(t, time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC), provider.Round(beforeEpoch, time.Minute), "Expected rounding across the Unix epoch")
+	assert.Equal(t, time.Date(1969, 12, 31, 23, 59, 0, 0, time.UTC), provider.Truncate(beforeEpoch, time.Minute), "Expected truncation before the Unix epoch")
+
+	// 不整除一天的時長以零時間對齊，而非每天的午夜
+	seven := 7 * time.Minute
+	assert.Equal(t, base.Truncate(seven), provider.Truncate(base, seven), "Expected standard library alignment")
+	assert.NotEqual(t, base, provider.Truncate(base, seven), "Expected noon not to be aligned to 7-minute buckets")
+}
+
 func TestUnix(t *testing.T) {
 	provider := GetProvider()
 	now := provider.Now()
@@ -149,6 +312,20 @@ func TestUnixMilli(t *testing.T) {
 	assert.Equal(t, now.UTC().UnixMilli(), unixMilli, "Expected Unix milli timestamp to match")
 }
 
+func TestUnixMicro(t *testing.T) {
+	provider := GetProvider()
+	now := provider.Now()
+	unixMicro := provider.UnixMicro(now)
+	assert.Equal(t, now.UTC().UnixMicro(), unixMicro, "Expected Unix micro timestamp to match")
+}
+
+func TestUnixNano(t *testing.T) {
+	provider := GetProvider()
+	now := provider.Now()
+	unixNano := provider.UnixNano(now)
+	assert.Equal(t, now.UTC().UnixNano(), unixNano, "Expected Unix nano timestamp to match")
+}
+
 func TestSetTimeScale(t *testing.T) {
 	provider := GetProvider()
 	provider.SetTimeScale(2.0)
@@ -157,6 +334,54 @@ func TestSetTimeScale(t *testing.T) {
 	assert.Equal(t, 1.0, provider.GetTimeScale(), "Expected time scale to be reset to 1.0")
 }
 
+func TestSetTimeScaleInvalid(t *testing.T) {
+	provider := NewProvider()
+	require.NoError(t, provider.SetTimeScale(2.0), "Expected valid time scale to be accepted")
+	before := provider.Now()
+
+	for _, scale := range []float64{-1, math.NaN(), math.Inf(1)} {
+		assert.Error(t, provider.SetTimeScale(scale), "Expected invalid time scale %v to return an error", scale)
+		assert.Equal(t, 2.0, provider.GetTimeScale(), "Expected previous time scale to be unchanged")
+	}
+	assert.WithinDuration(t, before, provider.Now(), 20*time.Millisecond, "Expected invalid time scale not to rebase the clock")
+
+	assert.Panics(t, func() { provider.MustSetTimeScale(-1) }, "Expected Must variant to panic on invalid time scale")
+	assert.NotPanics(t, func() { provider.MustSetTimeScale(3.0) }, "Expected Must variant to accept valid time scale")
+	assert.Equal(t, 3.0, provider.GetTimeScale(), "Expected Must variant to set the time scale")
+}
+
+func TestSetTimeScaleZero(t *testing.T) {
+	provider := NewProvider()
+	assert.Panics(t, func() { provider.MustSetTimeScale(-1) }, "Expected negative time scale to panic")
+
+	provider.SetTimeScale(0)
+	frozen := provider.Now()
+	timer := provider.NewTimer(time.Millisecond)
+	defer timer.Stop()
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, frozen, provider.Now(), "Expected time not to advance at scale 0")
+	select {
+	case <-timer.C:
+		t.Fatal("Expected timer not to fire at scale 0")
+	default:
+	}
+
+	provider.ClearTimeScale()
+	before := provider.Now()
+	time.Sleep(20 * time.Millisecond)
+	assert.True(t, provider.Now().After(before), "Expected time to advance after clearing scale 0")
+
+	// 模擬時間在比例為 0 時停住，恢復後從停住的時間繼續
+	mockTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	provider.SetMockTime(mockTime)
+	provider.SetTimeScale(0)
+	frozen = provider.Now()
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, frozen, provider.Now(), "Expected mock time not to advance at scale 0")
+	provider.ClearTimeScale()
+	assert.WithinDuration(t, frozen, provider.Now(), 10*time.Millisecond, "Expected mock time to continue from the frozen value")
+}
+
 func TestSetMockTime(t *testing.T) {
 	provider := GetProvider()
 	mockTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
@@ -168,9 +393,260 @@ func TestSetMockTime(t *testing.T) {
 	provider.ClearMockTime()
 }
 
+func TestFreezeNow(t *testing.T) {
+	provider := GetProvider()
+	defer provider.ClearMockTime()
+
+	frozen := provider.FreezeNow()
+	assert.WithinDuration(t, time.Now().UTC(), frozen, 10*time.Millisecond, "Expected frozen time to be the current time")
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, frozen, provider.Now(), "Expected time to stay frozen")
+
+	provider.SetTimeScale(2.0)
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, frozen, provider.Now(), "Expected time scale not to advance frozen time")
+	provider.ClearTimeScale()
+
+	provider.ClearMockTime()
+	assert.WithinDuration(t, time.Now().UTC(), provider.Now(), time.Millisecond, "Expected time to track real time after clearing")
+}
+
+func TestSetFrozenTime(t *testing.T) {
+	provider := GetProvider()
+	frozen := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	provider.SetFrozenTime(frozen)
+	defer provider.ClearMockTime()
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, frozen, provider.Now(), "Expected exact frozen value after sleeping")
+
+	provider.SetTimeScale(10.0)
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, frozen, provider.Now(), "Expected time scale not to advance frozen time")
+	provider.ClearTimeScale()
+
+	provider.AdvanceMockTime(time.Minute)
+	assert.Equal(t, frozen.Add(time.Minute), provider.Now(), "Expected AdvanceMockTime to move frozen time")
+}
+
+func TestReset(t *testing.T) {
+	provider := GetProvider()
+	provider.SetMockTime(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	provider.SetTimeScale(2.0)
+
+	provider.Reset()
+	assert.Equal(t, 1.0, provider.GetTimeScale(), "Expected time scale to be reset to 1.0")
+	assert.WithinDuration(t, time.Now().UTC(), provider.Now(), time.Millisecond, "Expected time to track real UTC after Reset")
+	assert.Equal(t, time.UTC, provider.Now().Location(), "Expected UTC time after Reset")
+}
+
+func TestAdvanceMockTime(t *testing.T) {
+	provider := GetProvider()
+	mockTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	provider.SetMockTime(mockTime)
+	defer provider.ClearMockTime()
+
+	time.Sleep(10 * time.Millisecond)
+	provider.AdvanceMockTime(time.Hour)
+	assert.Equal(t, mockTime.Add(time.Hour), provider.Now(), "Expected mock time to advance exactly by the given duration")
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, mockTime.Add(time.Hour), provider.Now(), "Expected stepped mock time not to drift")
+	provider.AdvanceMockTime(time.Minute)
+	assert.Equal(t, mockTime.Add(time.Hour+time.Minute), provider.Now(), "Expected steps to accumulate exactly")
+
+	frozen := provider.FreezeNow()
+	assert.Equal(t, provider.Now(), provider.Now(), "Expected consecutive Now() calls to return the identical instant")
+	provider.AdvanceMockTime(time.Hour)
+	assert.Equal(t, frozen.Add(time.Hour), provider.Now(), "Expected frozen time to advance exactly")
+
+	provider.ClearMockTime()
+	provider.AdvanceMockTime(time.Hour)
+	assert.WithinDuration(t, time.Now().UTC(), provider.Now(), 10*time.Millisecond, "Expected no effect without mock time")
+}
+
+func TestPauseAndResume(t *testing.T) {
+	provider := NewProvider()
+	// 未暫停時 Resume 無效果
+	provider.Resume()
+	assert.WithinDuration(t, time.Now().UTC(), provider.Now(), 10*time.Millisecond, "Expected resume without pause to have no effect")
+
+	before := provider.Now()
+	time.Sleep(20 * time.Millisecond)
+	assert.True(t, provider.Now().After(before), "Expected time to advance before pause")
+
+	provider.Pause()
+	paused := provider.Now()
+	time.Sleep(100 * time.Millisecond)
+	provider.Pause()
+	assert.Equal(t, paused, provider.Now(), "Expected time to stall while paused")
+
+	provider.Resume()
+	time.Sleep(20 * time.Millisecond)
+	resumed := provider.Now()
+	assert.True(t, resumed.After(paused), "Expected time to continue after resume")
+	assert.WithinDuration(t, paused.Add(20*time.Millisecond), resumed, 10*time.Millisecond, "Expected paused interval not to count")
+
+	t.Run("ScaledMockTime", func(t *testing.T) {
+		provider := NewProvider()
+		mockTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+		provider.SetMockTime(mockTime)
+		provider.SetTimeScale(60)
+
+		provider.Pause()
+		paused := provider.Now()
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(t, paused, provider.Now(), "Expected scaled mock time to stall while paused")
+
+		provider.Resume()
+		time.Sleep(20 * time.Millisecond)
+		assert.WithinDuration(t, paused.Add(1200*time.Millisecond), provider.Now(), 600*time.Millisecond, "Expected scaled time to continue from the paused value")
+
+		provider.Pause()
+		provider.AdvanceMockTime(time.Hour)
+		stepped := provider.Now()
+		assert.WithinDuration(t, mockTime.Add(time.Hour), stepped, 10*time.Millisecond, "Expected mock time to be adjustable while paused")
+		provider.Resume()
+		time.Sleep(20 * time.Millisecond)
+		assert.Equal(t, stepped, provider.Now(), "Expected stepped mock time not to drift after resume")
+	})
+}
+
+func TestRegisterClockChangeListener(t *testing.T) {
+	provider := NewProvider()
+	type change struct{ old, new time.Time }
+	var changes []change
+	provider.RegisterClockChangeListener(func(old, new time.Time) {
+		// 監聽器於釋放鎖後呼叫，可讀取更新後的時間
+		assert.WithinDuration(t, new, provider.Now(), 10*time.Millisecond, "Expected listener to observe the updated clock")
+		changes = append(changes, change{old, new})
+	})
+
+	mockTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	provider.SetMockTime(mockTime)
+	require.Len(t, changes, 1, "Expected SetMockTime to trigger the listener")
+	assert.WithinDuration(t, time.Now().UTC(), changes[0].old, 10*time.Millisecond, "Expected old value to be the previous time")
+	assert.Equal(t, mockTime, changes[0].new, "Expected new value to be the mock time")
+
+	provider.AdvanceMockTime(time.Hour)
+	require.Len(t, changes, 2, "Expected AdvanceMockTime to trigger the listener")
+	assert.Equal(t, mockTime.Add(time.Hour), changes[1].new, "Expected listener to receive the advanced time")
+
+	// 比例變更會重設基準，模擬時間不跳動
+	provider.SetTimeScale(2.0)
+	provider.FreezeNow()
+	assert.Len(t, changes, 2, "Expected continuous changes not to trigger the listener")
+
+	provider.ClearMockTime()
+	require.Len(t, changes, 3, "Expected ClearMockTime to trigger the listener")
+	assert.WithinDuration(t, time.Now().UTC(), changes[2].new, 10*time.Millisecond, "Expected new value to be the real time")
+
+	// 未模擬時清除時間加速會跳回真實時間
+	provider.SetTimeScale(1000)
+	time.Sleep(20 * time.Millisecond)
+	provider.ClearTimeScale()
+	require.Len(t, changes, 4, "Expected scale change that jumps the clock to trigger the listener")
+	assert.True(t, changes[3].new.Before(changes[3].old), "Expected clock to jump back to real time")
+}
+
+func TestConcurrentNowAndSetTimeScale(t *testing.T) {
+	provider := GetProvider()
+	defer provider.ClearTimeScale()
+	provider.SetTimeScale(2.0)
+
+	const readers = 8
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	regressions := make([]int, readers)
+	for i := 0; i < readers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			last := provider.Now()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				now := provider.Now()
+				if now.Before(last) {
+					regressions[i]++
+				}
+				last = now
+				provider.GetTimeScale()
+			}
+		}(i)
+	}
+
+	// 在大於 1 的比例間切換，每次切換都以當前時間為基準，時間不應倒退
+	for i := 0; i < 200; i++ {
+		provider.SetTimeScale(2.0 + float64(i%2))
+	}
+	close(stop)
+	wg.Wait()
+
+	for i, count := range regressions {
+		assert.Zero(t, count, "Expected reader %d never to observe time going backward", i)
+	}
+}
+
 func TestSingleton(t *testing.T) {
 	provider1 := GetProvider()
 	provider2 := GetProvider()
 
 	assert.Equal(t, provider1, provider2, "Expected both providers to be the same instance")
 }
+
+func TestNewProvider(t *testing.T) {
+	provider1 := NewProvider()
+	provider2 := NewProvider()
+	assert.NotSame(t, provider1, provider2, "Expected independent instances")
+	assert.NotSame(t, GetProvider(), provider1, "Expected instance separate from the singleton")
+
+	mockTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	provider1.SetFrozenTime(mockTime)
+	provider1.SetTimeScale(5.0)
+	assert.Equal(t, mockTime, provider1.Now(), "Expected mocked instance to use mock time")
+	assert.WithinDuration(t, time.Now().UTC(), provider2.Now(), 10*time.Millisecond, "Expected other instance to be unaffected")
+	assert.Equal(t, 1.0, provider2.GetTimeScale(), "Expected other instance to keep its own time scale")
+	assert.WithinDuration(t, time.Now().UTC(), GetProvider().Now(), 10*time.Millisecond, "Expected singleton to be unaffected")
+}
+
+func TestSetStripMonotonic(t *testing.T) {
+	provider := GetProvider()
+	defer provider.ClearMockTime()
+	defer provider.SetStripMonotonic(true)
+
+	nowFuncs := map[string]func() time.Time{
+		"real": func() time.Time {
+			provider.ClearMockTime()
+			return provider.Now()
+		},
+		"mock": func() time.Time {
+			provider.SetMockTime(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+			return provider.Now()
+		},
+		"scaled": func() time.Time {
+			provider.ClearMockTime()
+			provider.SetTimeScale(2.0)
+			defer provider.ClearTimeScale()
+			return provider.Now()
+		},
+	}
+
+	for name, nowFunc := range nowFuncs {
+		t.Run(name, func(t *testing.T) {
+			provider.SetStripMonotonic(true)
+			now := nowFunc()
+			assert.True(t, now == now.Round(0), "Expected stripped time to compare equal with ==")
+			assert.Equal(t, time.UTC, now.Location(), "Expected stripped time to be UTC")
+
+			provider.SetStripMonotonic(false)
+			now = nowFunc()
+			assert.False(t, now == now.Round(0), "Expected time to carry a monotonic reading")
+			assert.True(t, now.Equal(now.Round(0)), "Expected monotonic time to still be Equal to its wall time")
+			// UTC() 會去除單調時鐘讀數，保留時返回本地時區的時間
+			assert.Equal(t, time.Local, now.Location(), "Expected monotonic time to be in the local time zone")
+		})
+	}
+}
@@ -0,0 +1,279 @@
+package timeManagement
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// ScaledTimer 支持時間加速的計時器，觸發時於 C 發送時間提供者的當前時間；
+// 由 AfterFunc 建立時改為執行回調，C 為 nil。計時中時間加速比例改變時，依剩餘的時長重新計算真實等待時間
+type ScaledTimer struct {
+	C <-chan time.Time
+
+	c        chan time.Time
+	f        func()
+	provider *realTimeProvider
+
+	mu      sync.Mutex
+	timer   *time.Timer
+	wait    scaledWait
+	seq     uint64 // 每次排定底層計時器時遞增，過期的觸發依此忽略
+	pending bool
+	unwatch func()
+}
+
+// scaledWait 以時間提供者時間計算的等待，記錄尚需經過的時長及目前換算所用的比例
+type scaledWait struct {
+	remaining time.Duration
+	start     time.Time
+	scale     float64
+}
+
+func (r *realTimeProvider) newScaledWait(d time.Duration) scaledWait {
+	return scaledWait{remaining: d, start: time.Now(), scale: r.GetTimeScale()}
+}
+
+// rescale 扣除以原比例經過的時間後改用 scale，返回經過剩餘時長所需的真實時間
+func (w *scaledWait) rescale(scale float64) time.Duration {
+	now := time.Now()
+	w.remaining -= time.Duration(float64(now.Sub(w.start)) * w.scale)
+	w.start = now
+	w.scale = scale
+	return realDuration(w.remaining, w.scale)
+}
+
+// scaledDuration 返回在目前時間加速比例下，經過 d 模擬時間所需的真實時間
+func (r *realTimeProvider) scaledDuration(d time.Duration) time.Duration {
+	return realDuration(d, r.GetTimeScale())
+}
+
+// realDuration 返回在比例 scale 下經過 d 模擬時間所需的真實時間；
+// 比例為 0 時時間不會前進，正數的 d 返回最大時長，須待比例變更時重新計算
+func realDuration(d time.Duration, scale float64) time.Duration {
+	switch {
+	case scale == 1.0:
+		return d
+	case scale == 0 && d > 0:
+		return math.MaxInt64
+	case scale == 0:
+		return d
+	}
+	return time.Duration(float64(d) / scale)
+}
+
+// sleepScaled 等待經過 d 的時間提供者時間，時間加速比例改變時依剩餘時長重新計算真實等待時間；
+// done 先關閉時提前返回 false
+func (r *realTimeProvider) sleepScaled(d time.Duration, done <-chan struct{}) bool {
+	changed := make(chan struct{}, 1)
+	removeListener := r.addScaleListener(func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	})
+	defer removeListener()
+
+	wait := r.newScaledWait(d)
+	timer := time.NewTimer(realDuration(wait.remaining, wait.scale))
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.C:
+			return true
+		case <-changed:
+			timer.Reset(wait.rescale(r.GetTimeScale()))
+		case <-done:
+			return false
+		}
+	}
+}
+
+func (r *realTimeProvider) NewTimer(d time.Duration) *ScaledTimer {
+	c := make(chan time.Time, 1)
+	return r.newScaledTimer(c, nil, d)
+}
+
+// AfterFunc 在經過按時間加速比例換算的真實時間後，於獨立的協程執行 f。
+// 計時只依真實經過時間與加速比例，即使模擬時間已凍結仍會觸發
+func (r *realTimeProvider) AfterFunc(d time.Duration, f func()) *ScaledTimer {
+	return r.newScaledTimer(nil, f, d)
+}
+
+func (r *realTimeProvider) newScaledTimer(c chan time.Time, f func(), d time.Duration) *ScaledTimer {
+	t := &ScaledTimer{
+		C:        c,
+		c:        c,
+		f:        f,
+		provider: r,
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.startLocked(d)
+	return t
+}
+
+// startLocked 以 d 的時間提供者時間開始計時並監聽比例變更，呼叫者須持有 t.mu
+func (t *ScaledTimer) startLocked(d time.Duration) {
+	if t.unwatch == nil {
+		t.unwatch = t.provider.addScaleListener(t.rescale)
+	}
+	t.pending = true
+	t.wait = t.provider.newScaledWait(d)
+	t.armLocked(realDuration(t.wait.remaining, t.wait.scale))
+}
+
+// armLocked 以真實時間 d 排定底層計時器，呼叫者須持有 t.mu
+func (t *ScaledTimer) armLocked(d time.Duration) {
+	t.seq++
+	seq := t.seq
+	t.timer = time.AfterFunc(d, func() { t.fire(seq) })
+}
+
+// stopLocked 停止計時並移除比例監聽器，返回計時器原本是否仍在計時，呼叫者須持有 t.mu
+func (t *ScaledTimer) stopLocked() bool {
+	active := t.pending
+	t.pending = false
+	t.timer.Stop()
+	if t.unwatch != nil {
+		t.unwatch()
+		t.unwatch = nil
+	}
+	return active
+}
+
+// rescale 於時間加速比例變更後以剩餘時長重新排定底層計時器
+func (t *ScaledTimer) rescale() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.pending {
+		return
+	}
+	t.timer.Stop()
+	t.armLocked(t.wait.rescale(t.provider.GetTimeScale()))
+}
+
+func (t *ScaledTimer) fire(seq uint64) {
+	t.mu.Lock()
+	if seq != t.seq || !t.pending {
+		// 已停止、重設或重新排定
+		t.mu.Unlock()
+		return
+	}
+	t.stopLocked()
+	t.mu.Unlock()
+
+	if t.f != nil {
+		t.f()
+		return
+	}
+	select {
+	case t.c <- t.provider.Now():
+	default:
+	}
+}
+
+// drain 清除通道中尚未被接收的觸發時間
+func (t *ScaledTimer) drain() {
+	select {
+	case <-t.c:
+	default:
+	}
+}
+
+// Stop 停止計時器，若因此阻止了觸發返回 true，計時器已觸發或已停止時返回 false
+func (t *ScaledTimer) Stop() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	stopped := t.stopLocked()
+	t.drain()
+	return stopped
+}
+
+// Reset 以目前的時間加速比例重新計算 d 並重新開始計時，計時器原本仍在計時時返回 true
+func (t *ScaledTimer) Reset(d time.Duration) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	active := t.stopLocked()
+	t.drain()
+	t.startLocked(d)
+	return active
+}
+
+// ScaledTicker 支持時間加速的週期觸發器，每個週期依當時的時間加速比例重新計算真實間隔，
+// 觸發時於 C 發送時間提供者的當前時間
+type ScaledTicker struct {
+	C <-chan time.Time
+
+	reset    chan time.Duration
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+func (r *realTimeProvider) NewTicker(d time.Duration) *ScaledTicker {
+	if d <= 0 {
+		panic("Ticker interval must be positive")
+	}
+	c := make(chan time.Time, 1)
+	t := &ScaledTicker{
+		C:     c,
+		reset: make(chan time.Duration),
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	go t.run(r, c, d)
+	return t
+}
+
+func (t *ScaledTicker) run(r *realTimeProvider, c chan<- time.Time, d time.Duration) {
+	defer close(t.done)
+	changed := make(chan struct{}, 1)
+	removeListener := r.addScaleListener(func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	})
+	defer removeListener()
+
+	// 每個週期重新讀取時間加速比例，週期中途變更比例時以剩餘時長重新計算
+	wait := r.newScaledWait(d)
+	timer := time.NewTimer(realDuration(wait.remaining, wait.scale))
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.C:
+			select {
+			case c <- r.Now():
+			default:
+				// 與 time.Ticker 相同，接收端來不及處理時丟棄
+			}
+		case <-changed:
+			timer.Reset(wait.rescale(r.GetTimeScale()))
+			continue
+		case d = <-t.reset:
+		case <-t.stop:
+			return
+		}
+		wait = r.newScaledWait(d)
+		timer.Reset(realDuration(wait.remaining, wait.scale))
+	}
+}
+
+// Stop 停止觸發器並等待背景協程結束，返回後不再發送時間，重複呼叫無效果
+func (t *ScaledTicker) Stop() {
+	t.stopOnce.Do(func() { close(t.stop) })
+	<-t.done
+}
+
+// Reset 停止目前的週期並改以 d 為間隔重新開始計時
+func (t *ScaledTicker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("Ticker interval must be positive")
+	}
+	select {
+	case t.reset <- d:
+	case <-t.stop:
+	}
+}
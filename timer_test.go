@@ -0,0 +1,233 @@
+package timeManagement
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTimer(t *testing.T) {
+	provider := GetProvider()
+	mockTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	provider.SetMockTime(mockTime)
+	defer provider.ClearMockTime()
+
+	timer := provider.NewTimer(10 * time.Millisecond)
+	select {
+	case fired := <-timer.C:
+		assert.WithinDuration(t, mockTime.Add(10*time.Millisecond), fired, 50*time.Millisecond, "Expected fired time to come from the provider clock")
+	case <-time.After(time.Second):
+		require.Fail(t, "Expected timer to fire")
+	}
+	assert.False(t, timer.Stop(), "Expected Stop after firing to return false")
+}
+
+func TestScaledTimerStop(t *testing.T) {
+	provider := GetProvider()
+	timer := provider.NewTimer(50 * time.Millisecond)
+	assert.True(t, timer.Stop(), "Expected Stop before firing to stop the timer")
+	assert.False(t, timer.Stop(), "Expected a second Stop to return false")
+
+	select {
+	case <-timer.C:
+		assert.Fail(t, "Expected stopped timer not to fire")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestScaledTimerReset(t *testing.T) {
+	provider := GetProvider()
+	timer := provider.NewTimer(time.Hour)
+
+	provider.SetTimeScale(10.0)
+	defer provider.ClearTimeScale()
+
+	start := time.Now()
+	assert.True(t, timer.Reset(500*time.Millisecond), "Expected Reset of an active timer to return true")
+	select {
+	case <-timer.C:
+		elapsed := time.Since(start)
+		assert.Less(t, elapsed, 250*time.Millisecond, "Expected Reset to use the scaled duration")
+		assert.GreaterOrEqual(t, elapsed, 50*time.Millisecond, "Expected timer to wait the scaled duration")
+	case <-time.After(time.Second):
+		require.Fail(t, "Expected reset timer to fire")
+	}
+}
+
+func TestAfterFunc(t *testing.T) {
+	provider := GetProvider()
+	provider.SetTimeScale(10.0)
+	defer provider.ClearTimeScale()
+
+	fired := make(chan struct{})
+	start := time.Now()
+	provider.AfterFunc(500*time.Millisecond, func() { close(fired) })
+	select {
+	case <-fired:
+		assert.Less(t, time.Since(start), 250*time.Millisecond, "Expected AfterFunc to use the scaled duration")
+	case <-time.After(time.Second):
+		require.Fail(t, "Expected callback to run")
+	}
+}
+
+func TestAfterFuncStop(t *testing.T) {
+	provider := GetProvider()
+
+	fired := make(chan struct{}, 1)
+	timer := provider.AfterFunc(20*time.Millisecond, func() { fired <- struct{}{} })
+	assert.Nil(t, timer.C, "Expected AfterFunc timer to have no channel")
+	assert.True(t, timer.Stop(), "Expected Stop before firing to cancel the call")
+
+	select {
+	case <-fired:
+		assert.Fail(t, "Expected stopped callback not to run")
+	case <-time.After(60 * time.Millisecond):
+	}
+}
+
+func TestAfterFuncWhileFrozen(t *testing.T) {
+	provider := GetProvider()
+	provider.SetMockTime(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	provider.FreezeNow()
+	defer provider.ClearMockTime()
+
+	// 凍結的模擬時間不會前進，但計時仍依真實經過時間觸發
+	fired := make(chan struct{})
+	provider.AfterFunc(10*time.Millisecond, func() { close(fired) })
+	select {
+	case <-fired:
+	case <-time.After(time.Second):
+		require.Fail(t, "Expected callback to run while mock time is frozen")
+	}
+}
+
+// countTicks 返回 window 真實時間內從觸發器收到的次數
+func countTicks(ticker *ScaledTicker, window time.Duration) int {
+	deadline := time.After(window)
+	count := 0
+	for {
+		select {
+		case <-ticker.C:
+			count++
+		case <-deadline:
+			return count
+		}
+	}
+}
+
+func TestNewTicker(t *testing.T) {
+	provider := GetProvider()
+
+	ticker := provider.NewTicker(20 * time.Millisecond)
+	count := countTicks(ticker, 210*time.Millisecond)
+	ticker.Stop()
+	assert.InDelta(t, 10, count, 3, "Expected about 10 ticks at scale 1.0")
+
+	provider.SetTimeScale(2.0)
+	defer provider.ClearTimeScale()
+	ticker = provider.NewTicker(40 * time.Millisecond)
+	count = countTicks(ticker, 210*time.Millisecond)
+	ticker.Stop()
+	assert.InDelta(t, 10, count, 3, "Expected about 10 ticks at scale 2.0")
+}
+
+func TestScaledTickerPicksUpScaleChange(t *testing.T) {
+	provider := GetProvider()
+	defer provider.ClearTimeScale()
+
+	ticker := provider.NewTicker(20 * time.Millisecond)
+	defer ticker.Stop()
+	<-ticker.C
+
+	provider.SetTimeScale(2.0)
+	<-ticker.C // 變更比例前已排定的週期
+	count := countTicks(ticker, 210*time.Millisecond)
+	assert.InDelta(t, 20, count, 4, "Expected ticker to run at the new scale")
+}
+
+func TestScaledTickerStopAndReset(t *testing.T) {
+	provider := GetProvider()
+
+	ticker := provider.NewTicker(time.Hour)
+	ticker.Reset(20 * time.Millisecond)
+	select {
+	case <-ticker.C:
+	case <-time.After(time.Second):
+		require.Fail(t, "Expected ticker to use the reset interval")
+	}
+
+	ticker.Stop()
+	ticker.Stop()
+	ticker.Reset(time.Millisecond)
+	select {
+	case <-ticker.C:
+	default:
+	}
+	assert.Zero(t, countTicks(ticker, 50*time.Millisecond), "Expected no ticks after Stop")
+}
+
+func TestTimersResumeAfterScaleZero(t *testing.T) {
+	provider := NewProvider()
+	provider.MustSetTimeScale(0)
+
+	timer := provider.NewTimer(20 * time.Millisecond)
+	defer timer.Stop()
+	ticker := provider.NewTicker(20 * time.Millisecond)
+	defer ticker.Stop()
+	ctx, cancel := provider.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	after := provider.After(20 * time.Millisecond)
+	slept := make(chan struct{})
+	go func() {
+		provider.Sleep(20 * time.Millisecond)
+		close(slept)
+	}()
+
+	select {
+	case <-timer.C:
+		require.Fail(t, "Expected timer not to fire at scale 0")
+	case <-ticker.C:
+		require.Fail(t, "Expected ticker not to tick at scale 0")
+	case <-ctx.Done():
+		require.Fail(t, "Expected context not to expire at scale 0")
+	case <-after:
+		require.Fail(t, "Expected After not to fire at scale 0")
+	case <-slept:
+		require.Fail(t, "Expected Sleep not to return at scale 0")
+	case <-time.After(60 * time.Millisecond):
+	}
+
+	provider.ClearTimeScale()
+	for name, c := range map[string]<-chan time.Time{"timer": timer.C, "ticker": ticker.C, "After": after} {
+		select {
+		case <-c:
+		case <-time.After(time.Second):
+			require.Fail(t, "Expected "+name+" created at scale 0 to fire after ClearTimeScale")
+		}
+	}
+	for name, c := range map[string]<-chan struct{}{"context": ctx.Done(), "Sleep": slept} {
+		select {
+		case <-c:
+		case <-time.After(time.Second):
+			require.Fail(t, "Expected "+name+" created at scale 0 to finish after ClearTimeScale")
+		}
+	}
+}
+
+func TestScaledTimerRescale(t *testing.T) {
+	provider := NewProvider()
+
+	start := time.Now()
+	timer := provider.NewTimer(time.Second)
+	defer timer.Stop()
+	provider.MustSetTimeScale(10.0)
+	select {
+	case <-timer.C:
+		assert.Less(t, time.Since(start), 500*time.Millisecond, "Expected running timer to use the new scale for the remaining time")
+	case <-time.After(2 * time.Second):
+		require.Fail(t, "Expected rescaled timer to fire")
+	}
+}
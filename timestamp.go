@@ -0,0 +1,41 @@
+package timeManagement
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Timestamp 以 DateTimeFormatTZ 序列化為 JSON 的時間，解析後一律為UTC，精度為秒
+type Timestamp time.Time
+
+// NewTimestamp 以 t 建立 Timestamp
+func NewTimestamp(t time.Time) Timestamp {
+	return Timestamp(t.UTC())
+}
+
+// Time 返回對應的UTC時間
+func (ts Timestamp) Time() time.Time {
+	return time.Time(ts).UTC()
+}
+
+// MarshalJSON 以 DateTimeFormatTZ 將時間輸出為UTC字串
+func (ts Timestamp) MarshalJSON() ([]byte, error) {
+	return json.Marshal(GetProvider().Format(time.Time(ts), DateTimeFormatTZ))
+}
+
+// UnmarshalJSON 以 DateTimeFormatTZ 解析字串並轉換為UTC，JSON null 不做任何處理
+func (ts *Timestamp) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	t, err := GetProvider().Parse(DateTimeFormatTZ, value)
+	if err != nil {
+		return err
+	}
+	*ts = Timestamp(t)
+	return nil
+}
@@ -0,0 +1,35 @@
+package timeManagement
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimestampJSON(t *testing.T) {
+	location, err := time.LoadLocation("Asia/Taipei")
+	require.NoError(t, err, "Failed to load location")
+
+	type event struct {
+		At Timestamp `json:"at"`
+	}
+	original := event{At: NewTimestamp(time.Date(2023, 1, 2, 23, 4, 5, 0, location))}
+
+	data, err := json.Marshal(original)
+	require.NoError(t, err, "Failed to marshal timestamp")
+	assert.JSONEq(t, `{"at":"2023-01-02T15:04:05Z"}`, string(data), "Expected canonical UTC wire format")
+
+	var decoded event
+	require.NoError(t, json.Unmarshal(data, &decoded), "Failed to unmarshal timestamp")
+	assert.True(t, original.At.Time().Equal(decoded.At.Time()), "Expected round trip equality")
+	assert.Equal(t, time.UTC, decoded.At.Time().Location(), "Expected UTC time")
+
+	require.NoError(t, json.Unmarshal([]byte(`{"at":"2023-01-02T23:04:05+08:00"}`), &decoded), "Failed to unmarshal offset timestamp")
+	assert.Equal(t, time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC), decoded.At.Time(), "Expected offset timestamp to be normalized to UTC")
+
+	assert.Error(t, json.Unmarshal([]byte(`{"at":"2023-01-02"}`), &decoded), "Expected wrong layout to be rejected")
+	assert.Error(t, json.Unmarshal([]byte(`{"at":123}`), &decoded), "Expected non-string value to be rejected")
+}
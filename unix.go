@@ -0,0 +1,33 @@
+package timeManagement
+
+import (
+	"time"
+)
+
+func (r *realTimeProvider) FromUnix(sec int64) time.Time {
+	return time.Unix(sec, 0).UTC()
+}
+
+func (r *realTimeProvider) FromUnixMilli(msec int64) time.Time {
+	return time.UnixMilli(msec).UTC()
+}
+
+func (r *realTimeProvider) FromUnixNano(nsec int64) time.Time {
+	return time.Unix(0, nsec).UTC()
+}
+
+func (r *realTimeProvider) FromUnixBatch(secs []int64) []time.Time {
+	times := make([]time.Time, len(secs))
+	for i, sec := range secs {
+		times[i] = r.FromUnix(sec)
+	}
+	return times
+}
+
+func (r *realTimeProvider) FromUnixMilliBatch(msecs []int64) []time.Time {
+	times := make([]time.Time, len(msecs))
+	for i, msec := range msecs {
+		times[i] = r.FromUnixMilli(msec)
+	}
+	return times
+}
@@ -0,0 +1,49 @@
+package timeManagement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromUnix(t *testing.T) {
+	provider := GetProvider()
+	now := provider.Now()
+
+	fromUnix := provider.FromUnix(provider.Unix(now))
+	assert.Equal(t, now.Truncate(time.Second), fromUnix, "Expected round trip at second resolution")
+	assert.Equal(t, time.UTC, fromUnix.Location(), "Expected UTC time")
+
+	fromMilli := provider.FromUnixMilli(provider.UnixMilli(now))
+	assert.Equal(t, now.Truncate(time.Millisecond), fromMilli, "Expected round trip at millisecond resolution")
+
+	fromNano := provider.FromUnixNano(provider.UnixNano(now))
+	assert.True(t, now.Equal(fromNano), "Expected round trip at nanosecond resolution")
+	assert.Equal(t, time.UTC, fromNano.Location(), "Expected UTC time")
+}
+
+func TestFromUnixBatch(t *testing.T) {
+	provider := GetProvider()
+	secs := []int64{0, 1672574400, -86400, 4102444800}
+	times := provider.FromUnixBatch(secs)
+	require.Len(t, times, len(secs), "Expected one time per timestamp")
+	for i, sec := range secs {
+		assert.Equal(t, time.Unix(sec, 0).UTC(), times[i], "Expected batch element to match single conversion")
+		assert.Equal(t, time.UTC, times[i].Location(), "Expected UTC time")
+	}
+
+	assert.Empty(t, provider.FromUnixBatch(nil), "Expected empty result for empty input")
+}
+
+func TestFromUnixMilliBatch(t *testing.T) {
+	provider := GetProvider()
+	msecs := []int64{0, 1672574400123, -1}
+	times := provider.FromUnixMilliBatch(msecs)
+	require.Len(t, times, len(msecs), "Expected one time per timestamp")
+	for i, msec := range msecs {
+		assert.Equal(t, time.UnixMilli(msec).UTC(), times[i], "Expected batch element to match single conversion")
+		assert.Equal(t, msec, provider.UnixMilli(times[i]), "Expected batch element to round-trip")
+	}
+}
@@ -0,0 +1,88 @@
+package timeManagement
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// 時區資料庫來源
+const (
+	TZDataSourceEnv      = "ZONEINFO"
+	TZDataSourceSystem   = "system"
+	TZDataSourceEmbedded = "embedded"
+)
+
+// tzdataProbeZone 用於檢測時區資料庫的已知時區
+const tzdataProbeZone = "America/New_York"
+
+// zoneinfoDirs 作業系統常見的時區資料庫目錄，與標準庫的搜尋順序一致
+var zoneinfoDirs = []string{
+	"/usr/share/zoneinfo/",
+	"/usr/share/lib/zoneinfo/",
+	"/usr/lib/locale/TZ/",
+	"/etc/zoneinfo/",
+}
+
+// embeddedTZData 是否以 timetzdata 建置標籤內嵌時區資料庫
+var embeddedTZData = false
+
+// TZDataAvailable 嘗試載入已知時區，回報時區資料庫是否可用
+func TZDataAvailable() bool {
+	_, err := time.LoadLocation(tzdataProbeZone)
+	return err == nil
+}
+
+// TZDataSource 返回時區資料庫的來源：ZONEINFO 環境變數、作業系統或內嵌資料，
+// 皆無法使用時返回空字串
+func TZDataSource() string {
+	if zoneinfo := os.Getenv("ZONEINFO"); zoneinfo != "" {
+		if _, err := os.Stat(zoneinfo); err == nil {
+			return TZDataSourceEnv
+		}
+	}
+	for _, dir := range zoneinfoDirs {
+		if _, err := os.Stat(filepath.Join(dir, tzdataProbeZone)); err == nil {
+			return TZDataSourceSystem
+		}
+	}
+	if embeddedTZData {
+		return TZDataSourceEmbedded
+	}
+	return ""
+}
+
+// locationCache 快取已載入的時區，避免重複讀取時區資料庫
+var locationCache sync.Map
+
+// LoadLocation 載入指定名稱的時區，結果會被快取
+func LoadLocation(name string) (*time.Location, error) {
+	if loc, ok := locationCache.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	actual, _ := locationCache.LoadOrStore(name, loc)
+	return actual.(*time.Location), nil
+}
+
+func (r *realTimeProvider) NowInZones(zones []string) (map[string]time.Time, error) {
+	locations := make(map[string]*time.Location, len(zones))
+	for _, zone := range zones {
+		loc, err := LoadLocation(zone)
+		if err != nil {
+			return nil, err
+		}
+		locations[zone] = loc
+	}
+
+	now := r.Now()
+	result := make(map[string]time.Time, len(locations))
+	for zone, loc := range locations {
+		result[zone] = now.In(loc)
+	}
+	return result, nil
+}
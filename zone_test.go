@@ -0,0 +1,55 @@
+package timeManagement
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTZDataAvailable(t *testing.T) {
+	assert.True(t, TZDataAvailable(), "Expected tz database to be available")
+	assert.NotEmpty(t, TZDataSource(), "Expected tz database source to be reported")
+}
+
+func TestTZDataSourceEmbedded(t *testing.T) {
+	t.Setenv("ZONEINFO", "")
+	originalDirs, originalEmbedded := zoneinfoDirs, embeddedTZData
+	defer func() {
+		zoneinfoDirs, embeddedTZData = originalDirs, originalEmbedded
+	}()
+
+	zoneinfoDirs = nil
+	embeddedTZData = true
+	assert.Equal(t, TZDataSourceEmbedded, TZDataSource(), "Expected embedded source when system tz database is missing")
+
+	embeddedTZData = false
+	assert.Equal(t, "", TZDataSource(), "Expected empty source when no tz database is present")
+}
+
+func TestLoadLocation(t *testing.T) {
+	first, err := LoadLocation("Asia/Taipei")
+	require.NoError(t, err, "Failed to load location")
+	second, err := LoadLocation("Asia/Taipei")
+	require.NoError(t, err, "Failed to load cached location")
+	assert.Same(t, first, second, "Expected cached location to be reused")
+
+	_, err = LoadLocation("Invalid/Zone")
+	assert.Error(t, err, "Expected unknown zone to error")
+}
+
+func TestNowInZones(t *testing.T) {
+	provider := GetProvider()
+	zones := []string{"America/New_York", "Europe/London", "Asia/Tokyo"}
+	result, err := provider.NowInZones(zones)
+	require.NoError(t, err, "Failed to get time in zones")
+	require.Len(t, result, len(zones), "Expected a result for each zone")
+
+	for _, zone := range zones {
+		assert.Equal(t, zone, result[zone].Location().String(), "Expected location to match")
+		assert.True(t, result[zone].Equal(result[zones[0]]), "Expected all results to describe the same instant")
+	}
+
+	_, err = provider.NowInZones([]string{"Asia/Tokyo", "Invalid/Zone"})
+	assert.Error(t, err, "Expected unknown zone to error")
+}
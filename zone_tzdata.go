@@ -0,0 +1,7 @@
+//go:build timetzdata
+
+package timeManagement
+
+func init() {
+	embeddedTZData = true
+}